@@ -0,0 +1,152 @@
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam/token"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Supported values of the auth_type connection argument
+const (
+	authTypeAPIKey    = "api_key"
+	authTypeContainer = "container"
+	authTypeVPC       = "vpc"
+)
+
+// getAuthenticator returns the IAM authenticator shared by every service client of the connection
+func getAuthenticator(ctx context.Context, d *plugin.QueryData) (core.Authenticator, error) {
+	// Load authenticator from cache, so the IAM token is shared across clients
+	cacheKey := "ibm_authenticator"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(core.Authenticator), nil
+	}
+
+	// Every service client is built from the authenticator, so an invalid
+	// config fails each query of the connection with the same error
+	if err := validateConfig(d.Connection); err != nil {
+		plugin.Logger(ctx).Error("getAuthenticator", "config_error", err)
+		return nil, err
+	}
+
+	ibmConfig := GetConfig(d.Connection)
+
+	var authenticator core.Authenticator
+	switch authType := configAuthType(ibmConfig); authType {
+	case authTypeAPIKey:
+		apiKey, err := configApiKey(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		authenticator = &core.IamAuthenticator{
+			ApiKey: apiKey,
+			URL:    serviceEndpoint(d, endpointIAM, ""),
+		}
+	case authTypeContainer:
+		// Trusted profile login with a compute resource token, e.g. from an IKS pod
+		containerAuthenticator := &core.ContainerAuthenticator{
+			URL: serviceEndpoint(d, endpointIAM, ""),
+		}
+		if ibmConfig.TrustedProfileID != nil {
+			containerAuthenticator.IAMProfileID = *ibmConfig.TrustedProfileID
+		}
+		if ibmConfig.CRTokenFilename != nil {
+			containerAuthenticator.CRTokenFilename = *ibmConfig.CRTokenFilename
+		}
+		authenticator = containerAuthenticator
+	case authTypeVPC:
+		// Trusted profile login through the metadata service of a VPC virtual server instance
+		vpcAuthenticator := &core.VpcInstanceAuthenticator{}
+		if ibmConfig.TrustedProfileID != nil {
+			vpcAuthenticator.IAMProfileID = *ibmConfig.TrustedProfileID
+		}
+		authenticator = vpcAuthenticator
+	default:
+		return nil, fmt.Errorf("unsupported auth_type %q", authType)
+	}
+
+	if err := authenticator.Validate(); err != nil {
+		plugin.Logger(ctx).Error("getAuthenticator", "validation_error", err)
+		return nil, err
+	}
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, authenticator)
+
+	return authenticator, nil
+}
+
+// configAuthType returns the auth type of the connection. A trusted profile or
+// compute resource token file implies container authentication, otherwise an
+// API key is used.
+func configAuthType(ibmConfig ibmConfig) string {
+	if ibmConfig.AuthType != nil {
+		return *ibmConfig.AuthType
+	}
+	if ibmConfig.TrustedProfileID != nil || ibmConfig.CRTokenFilename != nil {
+		return authTypeContainer
+	}
+	return authTypeAPIKey
+}
+
+// tokenAuthenticator is implemented by the authenticators which fetch an IAM access token
+type tokenAuthenticator interface {
+	GetToken() (string, error)
+}
+
+// getAuthenticatorToken returns a valid IAM access token from the authenticator
+func getAuthenticatorToken(authenticator core.Authenticator) (string, error) {
+	tokenSource, ok := authenticator.(tokenAuthenticator)
+	if !ok {
+		return "", fmt.Errorf("authenticator %s does not provide IAM access tokens", authenticator.AuthenticationType())
+	}
+	return tokenSource.GetToken()
+}
+
+// authenticatorTransport sets the Authorization header of every request using
+// the connection authenticator. It is used by the clients which only accept a
+// static API key or access token.
+type authenticatorTransport struct {
+	authenticator core.Authenticator
+	transport     http.RoundTripper
+}
+
+func (t *authenticatorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	if err := t.authenticator.Authenticate(req); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// authenticatorCredentials is a COS credentials provider backed by the connection authenticator
+type authenticatorCredentials struct {
+	authenticator     core.Authenticator
+	serviceInstanceID string
+}
+
+func (c *authenticatorCredentials) Retrieve() (credentials.Value, error) {
+	accessToken, err := getAuthenticatorToken(c.authenticator)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	return credentials.Value{
+		Token: token.Token{
+			AccessToken: accessToken,
+			TokenType:   "Bearer",
+		},
+		ProviderName:      "SteampipeAuthenticatorProvider",
+		ProviderType:      "oauth",
+		ServiceInstanceID: c.serviceInstanceID,
+	}, nil
+}
+
+// IsExpired always returns true, the authenticator caches the token and refreshes it when required
+func (c *authenticatorCredentials) IsExpired() bool {
+	return true
+}
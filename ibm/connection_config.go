@@ -1,12 +1,57 @@
 package ibm
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
 type ibmConfig struct {
-	APIKey  *string  `hcl:"api_key"`
-	Regions []string `hcl:"regions,optional"`
+	APIKey           *string  `hcl:"api_key,optional"`
+	Regions          []string `hcl:"regions,optional"`
+	AuthType         *string  `hcl:"auth_type,optional"`
+	TrustedProfileID *string  `hcl:"trusted_profile_id,optional"`
+	CRTokenFilename  *string  `hcl:"cr_token_filename,optional"`
+
+	EndpointType *string            `hcl:"endpoint_type,optional"`
+	Endpoints    *ibmEndpointConfig `hcl:"endpoints,block"`
+
+	MaxRetries *int `hcl:"max_retries,optional"`
+	MaxBackoff *int `hcl:"max_backoff,optional"`
+
+	RateLimits *ibmRateLimitConfig `hcl:"rate_limits,block"`
+
+	ShowCredentials *bool `hcl:"show_credentials,optional"`
+}
+
+// ibmEndpointConfig holds per service endpoint overrides
+type ibmEndpointConfig struct {
+	IAM                *string `hcl:"iam,optional"`
+	VPC                *string `hcl:"vpc,optional"`
+	KMS                *string `hcl:"kms,optional"`
+	COS                *string `hcl:"cos,optional"`
+	CIS                *string `hcl:"cis,optional"`
+	ResourceController *string `hcl:"resource_controller,optional"`
+	ResourceManager    *string `hcl:"resource_manager,optional"`
+	GlobalTagging      *string `hcl:"global_tagging,optional"`
+	GlobalSearch       *string `hcl:"global_search,optional"`
+}
+
+// ibmRateLimitConfig holds per service request rate limits, in requests per second
+type ibmRateLimitConfig struct {
+	IAM                *int `hcl:"iam,optional"`
+	VPC                *int `hcl:"vpc,optional"`
+	KMS                *int `hcl:"kms,optional"`
+	COS                *int `hcl:"cos,optional"`
+	CIS                *int `hcl:"cis,optional"`
+	ResourceController *int `hcl:"resource_controller,optional"`
+	ResourceManager    *int `hcl:"resource_manager,optional"`
+	GlobalTagging      *int `hcl:"global_tagging,optional"`
+	GlobalSearch       *int `hcl:"global_search,optional"`
+	Account            *int `hcl:"account,optional"`
+	CertificateManager *int `hcl:"certificate_manager,optional"`
 }
 
 func ConfigInstance() interface{} {
@@ -21,3 +66,65 @@ func GetConfig(connection *plugin.Connection) ibmConfig {
 	config, _ := connection.Config.(ibmConfig)
 	return config
 }
+
+// validateConfig checks the connection config, returning an error which names
+// the connection and every invalid argument. The regions are validated against
+// the discovered regions by BuildRegionList.
+func validateConfig(connection *plugin.Connection) error {
+	if connection == nil {
+		return nil
+	}
+	ibmConfig := GetConfig(connection)
+
+	var problems []string
+
+	switch authType := configAuthType(ibmConfig); authType {
+	case authTypeAPIKey, authTypeContainer, authTypeVPC:
+	default:
+		problems = append(problems, fmt.Sprintf("invalid \"auth_type\": %s, must be one of %s, %s or %s", authType, authTypeAPIKey, authTypeContainer, authTypeVPC))
+	}
+
+	switch endpointType := configEndpointType(ibmConfig); endpointType {
+	case endpointTypePublic, endpointTypePrivate:
+	default:
+		problems = append(problems, fmt.Sprintf("invalid \"endpoint_type\": %s, must be %s or %s", endpointType, endpointTypePublic, endpointTypePrivate))
+	}
+
+	if ibmConfig.MaxRetries != nil && *ibmConfig.MaxRetries < 0 {
+		problems = append(problems, fmt.Sprintf("invalid \"max_retries\": %d, must be zero or greater", *ibmConfig.MaxRetries))
+	}
+
+	if ibmConfig.MaxBackoff != nil && *ibmConfig.MaxBackoff < 1 {
+		problems = append(problems, fmt.Sprintf("invalid \"max_backoff\": %d, must be at least 1 second", *ibmConfig.MaxBackoff))
+	}
+
+	for _, service := range rateLimitServices {
+		if limit := ibmConfig.RateLimits.get(service); limit != nil && *limit < 1 {
+			problems = append(problems, fmt.Sprintf("invalid \"rate_limits.%s\": %d, must be at least 1 request per second", service, *limit))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("connection %q has %s. Edit your connection configuration file and then restart Steampipe", connection.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// connectionConfigChanged validates the config of a changed connection when it
+// is loaded, and drops the clients and results of the previous config. The SDK
+// has no such hook for added connections and ignores the returned error, so
+// getAuthenticator still validates the config before the first request.
+func connectionConfigChanged(ctx context.Context, p *plugin.Plugin, old, new *plugin.Connection) error {
+	if err := p.ClearConnectionCache(ctx, new.Name); err != nil {
+		return err
+	}
+	if err := p.ClearQueryCache(ctx, new.Name); err != nil {
+		return err
+	}
+
+	if err := validateConfig(new); err != nil {
+		plugin.Logger(ctx).Error("connectionConfigChanged", "config_error", err)
+		return err
+	}
+	return nil
+}
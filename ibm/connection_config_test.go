@@ -0,0 +1,81 @@
+package ibm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestValidateConfig(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	strPtr := func(s string) *string { return &s }
+
+	cases := []struct {
+		name   string
+		config ibmConfig
+		want   []string
+	}{
+		{name: "default", config: ibmConfig{}},
+		{
+			name:   "invalid auth type",
+			config: ibmConfig{AuthType: strPtr("password")},
+			want:   []string{`invalid "auth_type": password`},
+		},
+		{
+			name: "several problems",
+			config: ibmConfig{
+				EndpointType: strPtr("direct"),
+				MaxRetries:   intPtr(-1),
+				MaxBackoff:   intPtr(0),
+			},
+			want: []string{`invalid "endpoint_type": direct`, `invalid "max_retries": -1`, `invalid "max_backoff": 0`},
+		},
+		{
+			name:   "invalid rate limit",
+			config: ibmConfig{RateLimits: &ibmRateLimitConfig{VPC: intPtr(10), CIS: intPtr(0)}},
+			want:   []string{`invalid "rate_limits.cis": 0`},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateConfig(&plugin.Connection{Name: "ibm_dev", Config: tc.config})
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("got error %v, want none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, want %v", tc.want)
+			}
+			if !strings.Contains(err.Error(), `connection "ibm_dev"`) {
+				t.Errorf("error %q does not name the connection", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectionConfigChanged(t *testing.T) {
+	cloud := newFakeCloud(t)
+	conn := newTestConnection(t, cloud.config())
+	conn.mustQuery("ibm_is_region", nil)
+
+	// The clients of the previous config are dropped, so the next query
+	// validates the changed config
+	conn.updateConfig(cloud.config() + "\nauth_type = \"password\"\n")
+	_, err := conn.query("ibm_is_region", nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid "auth_type": password`) {
+		t.Fatalf("got error %v, want the invalid auth_type", err)
+	}
+
+	conn.updateConfig(cloud.config())
+	if rows := conn.mustQuery("ibm_is_region", nil); len(rows) == 0 {
+		t.Errorf("got no regions after the config was fixed")
+	}
+}
@@ -0,0 +1,35 @@
+package ibm
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Storage classes of COS buckets, which end the location constraint of a
+// bucket, e.g. us-south-smart
+var cosStorageClasses = []string{"standard", "vault", "cold", "flex", "smart", "onerate_active"}
+
+// cosBucketLocation returns the location of a bucket from its location
+// constraint. The location is a region (us-south), a cross-region location
+// (us, eu or ap) or a single data center (ams03), and each has an endpoint
+// of the same form, e.g. s3.us.cloud-object-storage.appdomain.cloud.
+func cosBucketLocation(locationConstraint string) string {
+	i := strings.LastIndex(locationConstraint, "-")
+	if i < 0 || !slices.Contains(cosStorageClasses, locationConstraint[i+1:]) {
+		return locationConstraint
+	}
+	return locationConstraint[:i]
+}
+
+// cosBucketService returns the COS client for the endpoint of a bucket's location
+func cosBucketService(ctx context.Context, d *plugin.QueryData, bucket *s3.BucketExtended) (*s3.S3, error) {
+	location := ""
+	if bucket.LocationConstraint != nil {
+		location = cosBucketLocation(*bucket.LocationConstraint)
+	}
+	return cosService(ctx, d, d.EqualsQualString("instance_crn"), location)
+}
@@ -0,0 +1,126 @@
+package ibm
+
+import (
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Services whose endpoint can be set in the endpoints block of the connection config
+const (
+	endpointIAM                = "iam"
+	endpointVPC                = "vpc"
+	endpointKMS                = "kms"
+	endpointCOS                = "cos"
+	endpointCIS                = "cis"
+	endpointResourceController = "resource_controller"
+	endpointResourceManager    = "resource_manager"
+	endpointGlobalTagging      = "global_tagging"
+	endpointGlobalSearch       = "global_search"
+)
+
+// Supported values of the endpoint_type connection argument
+const (
+	endpointTypePublic  = "public"
+	endpointTypePrivate = "private"
+)
+
+// endpointTemplate holds the public and private endpoint of a service. The
+// {region} placeholder is replaced by the region of the request.
+type endpointTemplate struct {
+	public  string
+	private string
+}
+
+var endpointTemplates = map[string]endpointTemplate{
+	endpointIAM: {
+		public:  "https://iam.cloud.ibm.com",
+		private: "https://private.iam.cloud.ibm.com",
+	},
+	endpointVPC: {
+		public:  "https://{region}.iaas.cloud.ibm.com/v1",
+		private: "https://{region}.private.iaas.cloud.ibm.com/v1",
+	},
+	endpointKMS: {
+		public:  "https://{region}.kms.cloud.ibm.com",
+		private: "https://private.{region}.kms.cloud.ibm.com",
+	},
+	endpointCOS: {
+		public:  "s3.{region}.cloud-object-storage.appdomain.cloud",
+		private: "s3.private.{region}.cloud-object-storage.appdomain.cloud",
+	},
+	endpointCIS: {
+		public:  "https://api.cis.cloud.ibm.com",
+		private: "https://api.private.cis.cloud.ibm.com",
+	},
+	endpointResourceController: {
+		public:  "https://resource-controller.cloud.ibm.com",
+		private: "https://private.resource-controller.cloud.ibm.com",
+	},
+	endpointResourceManager: {
+		public:  "https://resource-controller.cloud.ibm.com",
+		private: "https://private.resource-controller.cloud.ibm.com",
+	},
+	endpointGlobalTagging: {
+		public:  "https://tags.global-search-tagging.cloud.ibm.com",
+		private: "https://tags.private.global-search-tagging.cloud.ibm.com",
+	},
+	endpointGlobalSearch: {
+		public:  "https://api.global-search-tagging.cloud.ibm.com",
+		private: "https://api.private.global-search-tagging.cloud.ibm.com",
+	},
+}
+
+// serviceEndpoint returns the endpoint of a service in the given region. An
+// override in the endpoints block of the connection config takes precedence
+// over the public or private endpoint selected by endpoint_type.
+func serviceEndpoint(d *plugin.QueryData, service string, region string) string {
+	ibmConfig := GetConfig(d.Connection)
+
+	var endpoint string
+	if override := ibmConfig.Endpoints.get(service); override != nil {
+		endpoint = *override
+	} else if configEndpointType(ibmConfig) == endpointTypePrivate {
+		endpoint = endpointTemplates[service].private
+	} else {
+		endpoint = endpointTemplates[service].public
+	}
+
+	return strings.ReplaceAll(endpoint, "{region}", region)
+}
+
+// configEndpointType returns the endpoint type of the connection, public by default
+func configEndpointType(ibmConfig ibmConfig) string {
+	if ibmConfig.EndpointType != nil {
+		return *ibmConfig.EndpointType
+	}
+	return endpointTypePublic
+}
+
+// get returns the endpoint override for a service, if any
+func (e *ibmEndpointConfig) get(service string) *string {
+	if e == nil {
+		return nil
+	}
+	switch service {
+	case endpointIAM:
+		return e.IAM
+	case endpointVPC:
+		return e.VPC
+	case endpointKMS:
+		return e.KMS
+	case endpointCOS:
+		return e.COS
+	case endpointCIS:
+		return e.CIS
+	case endpointResourceController:
+		return e.ResourceController
+	case endpointResourceManager:
+		return e.ResourceManager
+	case endpointGlobalTagging:
+		return e.GlobalTagging
+	case endpointGlobalSearch:
+		return e.GlobalSearch
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package ibm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testAccountID is the account of the access tokens issued by the fake IAM service
+const testAccountID = "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4"
+
+// fakeService is a fake IBM Cloud API, which replays the recorded responses
+// in testdata and records every request it receives
+type fakeService struct {
+	*httptest.Server
+
+	t   *testing.T
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newFakeService(t *testing.T) *fakeService {
+	t.Helper()
+
+	s := &fakeService{
+		t:   t,
+		mux: http.NewServeMux(),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeService) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Clone(r.Context()))
+	s.mu.Unlock()
+
+	// Unknown requests fail like the IBM APIs, so a missing fixture fails the test
+	if _, pattern := s.mux.Handler(r); pattern == "" {
+		s.t.Logf("fake service %s: no fixture for %s %s", s.URL, r.Method, r.URL)
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{
+			"errors": []map[string]string{{"code": "not_found", "message": "Not Found"}},
+		})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// handle serves the requests matching an http.ServeMux pattern, e.g. "GET /v1/vpcs/{id}"
+func (s *fakeService) handle(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// fixture serves the requests matching a pattern with a recorded response,
+// in JSON or, for the S3 API of Cloud Object Storage, in XML
+func (s *fakeService) fixture(pattern string, name string) {
+	body := readFixture(s.t, name)
+	contentType := "application/json"
+	if filepath.Ext(name) == ".xml" {
+		contentType = "application/xml"
+	}
+	s.handle(pattern, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(body)
+	})
+}
+
+// requestsTo returns the recorded requests to a path
+func (s *fakeService) requestsTo(path string) []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := []*http.Request{}
+	for _, r := range s.requests {
+		if r.URL.Path == path {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// readFixture returns the content of a file in testdata
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return body
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// fakeCloud holds the fake services of a connection
+type fakeCloud struct {
+	IAM                *fakeService
+	VPC                *fakeService
+	KMS                *fakeService
+	COS                *fakeService
+	CIS                *fakeService
+	ResourceController *fakeService
+	GlobalSearch       *fakeService
+	UserManagement     *fakeService
+	CertificateManager *fakeService
+}
+
+// newFakeCloud starts the fake services, with the requests made by every
+// query, i.e. IAM tokens and region discovery, already handled
+func newFakeCloud(t *testing.T) *fakeCloud {
+	t.Helper()
+
+	c := &fakeCloud{
+		IAM:                newFakeService(t),
+		VPC:                newFakeService(t),
+		KMS:                newFakeService(t),
+		COS:                newFakeService(t),
+		CIS:                newFakeService(t),
+		ResourceController: newFakeService(t),
+		GlobalSearch:       newFakeService(t),
+		UserManagement:     newFakeService(t),
+		CertificateManager: newFakeService(t),
+	}
+
+	c.IAM.handle("POST /identity/token", func(w http.ResponseWriter, _ *http.Request) {
+		now := time.Now().Unix()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":  testAccessToken(now),
+			"refresh_token": "not_a_refresh_token",
+			"token_type":    "Bearer",
+			"expires_in":    3600,
+			"expiration":    now + 3600,
+		})
+	})
+	c.VPC.fixture("GET /v1/regions", "vpc/regions.json")
+	c.GlobalSearch.fixture("POST /v3/resources/search", "global_search/search.json")
+
+	// The bluemix-go clients of the user and certificate manager tables only
+	// read their endpoints from the environment
+	t.Setenv("IBMCLOUD_USER_MANAGEMENT_ENDPOINT", c.UserManagement.URL)
+	t.Setenv("IBMCLOUD_CERTIFICATE_MANAGER_API_ENDPOINT", c.CertificateManager.URL)
+
+	return c
+}
+
+// config returns the connection config, with every endpoint pointed at the
+// fake services. Failed requests are not retried.
+func (c *fakeCloud) config() string {
+	return c.configWithRetries(0)
+}
+
+// configWithRetries returns the connection config, with the given maximum
+// number of retries for a request
+func (c *fakeCloud) configWithRetries(maxRetries int) string {
+	return fmt.Sprintf(`
+api_key     = "not_an_api_key"
+max_retries = %d
+max_backoff = 1
+
+endpoints {
+  iam                 = %q
+  vpc                 = %q
+  kms                 = %q
+  cos                 = %q
+  cis                 = %q
+  resource_controller = %q
+  global_search       = %q
+}
+`, maxRetries, c.IAM.URL, c.VPC.URL+"/v1", c.KMS.URL, c.COS.URL, c.CIS.URL, c.ResourceController.URL, c.GlobalSearch.URL)
+}
+
+// testAccessToken returns an unsigned IAM access token for the test account.
+// The plugin reads the claims of the token without verifying it.
+func testAccessToken(issuedAt int64) string {
+	segment := func(value interface{}) string {
+		data, _ := json.Marshal(value)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	header := segment(map[string]string{"alg": "HS256", "typ": "JWT"})
+	claims := segment(map[string]interface{}{
+		"id":      "IBMid-test",
+		"iss":     "https://iam.cloud.ibm.com/identity",
+		"iat":     issuedAt,
+		"exp":     issuedAt + 3600,
+		"account": map[string]string{"bss": testAccountID},
+	})
+	return header + "." + claims + ".c2lnbmF0dXJl"
+}
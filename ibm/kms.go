@@ -0,0 +1,307 @@
+package ibm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Service types of the key management services, which share the Key Protect API
+const (
+	kmsTypeKeyProtect = "kms"
+	kmsTypeHPCS       = "hs-crypto"
+)
+
+// getHpcsEndpoint returns the key management endpoint of a Hyper Protect
+// Crypto Services instance. Unlike Key Protect, every HPCS instance has its own
+// endpoint, which is listed in the extensions of the resource instance.
+func getHpcsEndpoint(ctx context.Context, d *plugin.QueryData, instanceID string) (string, error) {
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		return "", err
+	}
+
+	instance, resp, err := conn.GetResourceInstanceWithContext(ctx, &resourcecontrollerv2.GetResourceInstanceOptions{
+		ID: &instanceID,
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("getHpcsEndpoint", "query_error", err, "resp", resp)
+		return "", err
+	}
+
+	endpointType := configEndpointType(GetConfig(d.Connection))
+	endpoints, _ := instance.Extensions["endpoints"].(map[string]interface{})
+	endpoint, _ := endpoints[endpointType].(string)
+	if endpoint == "" {
+		return "", fmt.Errorf("no %s key management endpoint found for Hyper Protect Crypto Services instance %s", endpointType, instanceID)
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	return endpoint, nil
+}
+
+// isKmsMatrixInstance returns true if the matrix item of a list or get function
+// is a key management instance which the query requested, and raises any error
+// from building the service instance matrix
+func isKmsMatrixInstance(d *plugin.QueryData) (bool, error) {
+	instanceID := d.EqualsQualString("instance_id")
+	serviceType := d.EqualsQualString("service_type")
+
+	// Raise any error from building the service instance matrix
+	if err := getMatrixError(d); err != nil {
+		return false, err
+	}
+
+	// Invalid service type
+	if serviceType != kmsTypeKeyProtect && serviceType != kmsTypeHPCS {
+		return false, nil
+	}
+
+	// Return if specified instanceID not matched
+	if d.EqualsQuals["instance_id"] != nil && d.EqualsQuals["instance_id"].GetStringValue() != instanceID {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// kmsMatrixService returns the client of the instance of the matrix item of a
+// list or get function, or nil if the matrix item is not a requested key
+// management instance. The name of the function is used in the logs.
+func kmsMatrixService(ctx context.Context, d *plugin.QueryData, name string) (*kp.Client, error) {
+	if ok, err := isKmsMatrixInstance(d); !ok {
+		return nil, err
+	}
+
+	conn, err := kmsService(ctx, d, d.EqualsQualString("service_type"), d.EqualsQualString("region"), d.EqualsQualString("instance_id"))
+	if err != nil {
+		plugin.Logger(ctx).Error(name, "connection_error", err)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// kmsListPages calls listPage with the offset of every page of a Key Protect
+// list API. listPage returns the number of resources of the page, and a short
+// page is the last one, so listPage can also return zero to stop early, e.g.
+// when the row limit has been hit.
+func kmsListPages(limit int, listPage func(offset int) (int, error)) error {
+	for offset := 0; ; offset += limit {
+		count, err := listPage(offset)
+		if err != nil || count < limit {
+			return err
+		}
+	}
+}
+
+// kmsRequestOptions are added to the Key Protect requests made with a context.
+// keyprotect-go-client only supports a key ring set on the shared client
+// config, and does not support every query parameter of the API, e.g. the
+// state and extractable filters of the list keys API.
+type kmsRequestOptions struct {
+	KeyRing string
+	Query   url.Values
+}
+
+// kmsRequestOptionsKey is the context key of the Key Protect request options
+type kmsRequestOptionsKey struct{}
+
+// withKmsRequestOptions returns a context which adds the given options to the
+// Key Protect requests made with it
+func withKmsRequestOptions(ctx context.Context, opts *kmsRequestOptions) context.Context {
+	return context.WithValue(ctx, kmsRequestOptionsKey{}, opts)
+}
+
+// kmsRequestTransport adds the options of the request context to every request
+type kmsRequestTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *kmsRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	opts, ok := req.Context().Value(kmsRequestOptionsKey{}).(*kmsRequestOptions)
+	if !ok || opts == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	if opts.KeyRing != "" {
+		req.Header.Set("x-kms-key-ring", opts.KeyRing)
+	}
+	if len(opts.Query) > 0 {
+		values := req.URL.Query()
+		for key, value := range opts.Query {
+			values[key] = value
+		}
+		req.URL.RawQuery = values.Encode()
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// kmsGet sends a GET request to a Key Protect API which keyprotect-go-client
+// does not support, and decodes the JSON response into result. The request
+// is sent with the client's instance, transport and retry policy.
+func kmsGet(ctx context.Context, conn *kp.Client, path string, query url.Values, result interface{}) error {
+	u, err := conn.URL.Parse(path)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("accept", "application/json")
+	req.Header.Set("bluemix-instance", conn.Config.InstanceID)
+
+	// The client's transport retries the request with the connection retry policy
+	resp, err := conn.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Errors are returned like the errors of the client
+	if resp.StatusCode != http.StatusOK {
+		return &kp.Error{
+			URL:         u.String(),
+			StatusCode:  resp.StatusCode,
+			Message:     string(body),
+			BodyContent: body,
+		}
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// getKmsKeys returns every key of the client's instance, or only the given
+// key if keyID is set. No keys are returned if the key belongs to another instance.
+func getKmsKeys(ctx context.Context, conn *kp.Client, keyID string) ([]kp.Key, error) {
+	if keyID != "" {
+		key, err := conn.GetKeyMetadata(ctx, keyID)
+		if err != nil {
+			if isKmsNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []kp.Key{*key}, nil
+	}
+
+	// 5000 is the maximum number of keys per page
+	limit := 5000
+
+	keys := []kp.Key{}
+	err := kmsListPages(limit, func(offset int) (int, error) {
+		data, err := conn.GetKeys(ctx, limit, offset)
+		if err != nil {
+			return 0, err
+		}
+		keys = append(keys, data.Keys...)
+		return len(data.Keys), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// getKmsTotalCount returns the total number of resources of a Key Protect
+// list API, e.g. keys, without listing them
+func getKmsTotalCount(ctx context.Context, conn *kp.Client, path string) (int, error) {
+	query := url.Values{}
+	query.Set("limit", "1")
+	query.Set("totalCount", "true")
+
+	var page struct {
+		Metadata struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"metadata"`
+	}
+	if err := kmsGet(ctx, conn, path, query, &page); err != nil {
+		return 0, err
+	}
+
+	return page.Metadata.TotalCount, nil
+}
+
+// kmsKeyVersion is a version of the key material of a key
+type kmsKeyVersion struct {
+	ID           string     `json:"id"`
+	CreationDate *time.Time `json:"creationDate"`
+}
+
+// getKmsKeyVersions returns every version of a key, including the versions
+// of keys which are not active
+func getKmsKeyVersions(ctx context.Context, conn *kp.Client, keyID string) ([]kmsKeyVersion, error) {
+	// 5000 is the maximum number of versions per page
+	limit := 5000
+
+	versions := []kmsKeyVersion{}
+	err := kmsListPages(limit, func(offset int) (int, error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("allKeyStates", "true")
+
+		var page struct {
+			Versions []kmsKeyVersion `json:"resources"`
+		}
+		if err := kmsGet(ctx, conn, "keys/"+url.PathEscape(keyID)+"/versions", query, &page); err != nil {
+			return 0, err
+		}
+		versions = append(versions, page.Versions...)
+		return len(page.Versions), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// isKmsNotFoundError returns true if a Key Protect request failed as the
+// resource does not exist, e.g. a key of another instance
+func isKmsNotFoundError(err error) bool {
+	var kpErr *kp.Error
+	return errors.As(err, &kpErr) && kpErr.StatusCode == http.StatusNotFound
+}
+
+// isKmsUnsupportedError returns true if a Key Protect request failed as the
+// instance does not support the API. Hyper Protect Crypto Services only
+// implements part of the Key Protect API, e.g. not import tokens or KMIP
+// adapters, and rejects the requests to the other APIs with 501 Not
+// Implemented rather than 404 Not Found. Other errors, e.g. 400 Bad Request
+// for an invalid request or 403 Forbidden for a missing IAM role, are not ignored.
+func isKmsUnsupportedError(err error, kmsType string) bool {
+	if isKmsNotFoundError(err) {
+		return true
+	}
+	if kmsType != kmsTypeHPCS {
+		return false
+	}
+	var kpErr *kp.Error
+	return errors.As(err, &kpErr) && kpErr.StatusCode == http.StatusNotImplemented
+}
@@ -0,0 +1,146 @@
+package ibm
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testKmsOtherInstanceID = "c7d8e9f0-1a2b-4c3d-8e4f-5a6b7c8d9e0f"
+
+// kmsBarrier holds the requests to the fake Key Protect service until a
+// request of every instance has arrived, so the requests of the instances are
+// in flight at the same time
+type kmsBarrier struct {
+	t       *testing.T
+	size    int
+	mu      sync.Mutex
+	arrived int
+	release chan struct{}
+}
+
+func newKmsBarrier(t *testing.T, size int) *kmsBarrier {
+	return &kmsBarrier{t: t, size: size, release: make(chan struct{})}
+}
+
+func (b *kmsBarrier) wait() {
+	b.mu.Lock()
+	release := b.release
+	b.arrived++
+	if b.arrived == b.size {
+		close(release)
+		b.arrived = 0
+		b.release = make(chan struct{})
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-release:
+	case <-time.After(5 * time.Second):
+		b.t.Errorf("the requests of the Key Protect instances were not sent concurrently")
+	}
+}
+
+// TestKmsConcurrentInstances queries two Key Protect instances of the same
+// region at the same time. Every instance has its own client, which must send
+// the instance and the request options of its own query.
+func TestKmsConcurrentInstances(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/kms_instances.json")
+
+	// Every instance has a single key, named after the instance
+	barrier := newKmsBarrier(t, 2)
+	cloud.KMS.handle("GET /api/v2/keys", func(w http.ResponseWriter, r *http.Request) {
+		barrier.wait()
+
+		instanceID := r.Header.Get("bluemix-instance")
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"metadata": map[string]interface{}{"collectionType": "application/vnd.ibm.kms.key+json", "collectionTotal": 1},
+			"resources": []map[string]interface{}{
+				{
+					"id":        "key-of-" + instanceID,
+					"name":      "key-of-" + instanceID,
+					"crn":       "crn:v1:bluemix:public:kms:us-south:a/" + testAccountID + ":" + instanceID + ":key:key-of-" + instanceID,
+					"state":     1,
+					"keyRingID": r.Header.Get("x-kms-key-ring"),
+				},
+			},
+		})
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	t.Run("list every instance", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_kms_key", nil, "name", "instance_id")
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, want 2", len(rows))
+		}
+		keys := rowsByColumn(t, rows, "name")
+		for _, instanceID := range []string{testKmsInstanceID, testKmsOtherInstanceID} {
+			assertColumns(t, keys["key-of-"+instanceID], map[string]interface{}{"instance_id": instanceID})
+		}
+	})
+
+	t.Run("concurrent queries", func(t *testing.T) {
+		queries := map[string]map[string]interface{}{
+			testKmsInstanceID:      {"instance_id": testKmsInstanceID, "key_ring_id": "ring-a", "state": "1"},
+			testKmsOtherInstanceID: {"instance_id": testKmsOtherInstanceID, "key_ring_id": "ring-b", "extractable": true},
+		}
+
+		var wg sync.WaitGroup
+		results := make(map[string][]map[string]interface{})
+		var mu sync.Mutex
+		for instanceID, quals := range queries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rows, err := conn.query("ibm_kms_key", quals, "name", "instance_id", "key_ring_id")
+				if err != nil {
+					t.Errorf("select from ibm_kms_key where instance_id = %s failed: %v", instanceID, err)
+					return
+				}
+				mu.Lock()
+				results[instanceID] = rows
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		// Every query only returns the key of its own instance
+		for instanceID, quals := range queries {
+			rows := results[instanceID]
+			if len(rows) != 1 {
+				t.Errorf("instance %s: got %d rows, want 1", instanceID, len(rows))
+				continue
+			}
+			assertColumns(t, rows[0], map[string]interface{}{
+				"name":        "key-of-" + instanceID,
+				"instance_id": instanceID,
+				"key_ring_id": quals["key_ring_id"],
+			})
+		}
+
+		// The key ring and filters of a query are only sent to its own instance
+		for _, r := range cloud.KMS.requestsTo("/api/v2/keys") {
+			instanceID := r.Header.Get("bluemix-instance")
+			keyRing := r.Header.Get("x-kms-key-ring")
+			query := r.URL.Query()
+			if keyRing == "" {
+				continue
+			}
+
+			switch instanceID {
+			case testKmsInstanceID:
+				if keyRing != "ring-a" || query.Get("state") != "1" || query.Has("extractable") {
+					t.Errorf("instance %s: got key ring %q and query %q, want key ring ring-a and state 1", instanceID, keyRing, query.Encode())
+				}
+			case testKmsOtherInstanceID:
+				if keyRing != "ring-b" || query.Get("extractable") != "true" || query.Has("state") {
+					t.Errorf("instance %s: got key ring %q and query %q, want key ring ring-b and extractable true", instanceID, keyRing, query.Encode())
+				}
+			default:
+				t.Errorf("unexpected bluemix-instance %q", instanceID)
+			}
+		}
+	})
+}
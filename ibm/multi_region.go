@@ -2,15 +2,18 @@ package ibm
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
-// Regions is the current known list of valid regions
+// Regions is the list of known regions, used when the regions cannot be discovered
 func Regions() []string {
 	return []string{
 		"au-syd",
@@ -25,8 +28,62 @@ func Regions() []string {
 	}
 }
 
+// defaultRegion is the region used when the connection has no region
+const defaultRegion = "us-south"
+
+// fallbackRegionsTTL is how long the static Regions list is used, when the
+// regions cannot be discovered, before the VPC API is tried again
+const fallbackRegionsTTL = 5 * time.Minute
+
+// listAvailableRegions returns the available regions from the VPC API, cached
+// per connection. Falls back to the static Regions list, cached for a short
+// time, when the API cannot be reached or returns no available region.
+func listAvailableRegions(ctx context.Context, d *plugin.QueryData) []string {
+	cacheKey := "ibm_available_regions"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]string)
+	}
+
+	fallback := func(logArgs ...interface{}) []string {
+		plugin.Logger(ctx).Warn("listAvailableRegions", append(logArgs, "fallback", "static region list")...)
+		d.ConnectionManager.Cache.SetWithTTL(cacheKey, Regions(), fallbackRegionsTTL)
+		return Regions()
+	}
+
+	// Every region endpoint returns the full list of regions, so they are
+	// listed from the region the connection is configured for, when known
+	region := configuredRegion(d)
+	if !slices.Contains(Regions(), region) {
+		region = defaultRegion
+	}
+
+	conn, err := vpcService(ctx, d, region)
+	if err != nil {
+		return fallback("connection_error", err)
+	}
+
+	result, resp, err := conn.ListRegionsWithContext(ctx, &vpcv1.ListRegionsOptions{})
+	if err != nil {
+		return fallback("query_error", err, "resp", resp)
+	}
+
+	regions := []string{}
+	for _, region := range result.Regions {
+		if region.Status != nil && *region.Status == vpcv1.RegionStatusAvailableConst {
+			regions = append(regions, *region.Name)
+		}
+	}
+	if len(regions) == 0 {
+		return fallback("query_error", "no available regions")
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, regions)
+
+	return regions
+}
+
 // BuildRegionList :: return a list of matrix items, one per region specified in the connection config
-func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interface{} {
+func BuildRegionList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
 
 	// cache matrix
 	cacheKey := "RegionListMatrix"
@@ -39,7 +96,15 @@ func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interf
 	// retrieve regions from connection config
 	ibmConfig := GetConfig(d.Connection)
 	if ibmConfig.Regions != nil {
-		regions := Regions()
+		regions := listAvailableRegions(ctx, d)
+
+		// Return the error as a matrix item, so it is raised by the table's hydrate functions
+		if invalidPatterns := getInvalidRegionPatterns(ibmConfig.Regions, regions); len(invalidPatterns) > 0 {
+			return []map[string]interface{}{
+				{matrixErrorKey: fmt.Sprintf("connection %q has invalid \"regions\": %s. Edit your connection configuration file and then restart Steampipe", d.Connection.Name, strings.Join(invalidPatterns, ", "))},
+			}
+		}
+
 		for _, pattern := range ibmConfig.Regions {
 			for _, validRegion := range regions {
 				if ok, _ := path.Match(pattern, validRegion); ok {
@@ -53,11 +118,6 @@ func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interf
 	if len(allRegions) > 0 {
 		uniqueRegions := unique(allRegions)
 
-		if len(getInvalidRegions(uniqueRegions)) > 0 {
-			panic("\n\nConnection config have invalid regions: " + strings.Join(getInvalidRegions(uniqueRegions), ","))
-		}
-
-		// validate regions list
 		matrix := make([]map[string]interface{}, len(uniqueRegions))
 		for i, region := range uniqueRegions {
 			matrix[i] = map[string]interface{}{"region": region}
@@ -70,7 +130,7 @@ func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interf
 	}
 
 	// Search for region configured using env, or use default region (i.e. us-south)
-	defaultIBMRegion := GetDefaultIBMRegion(d)
+	defaultIBMRegion := GetDefaultIBMRegion(ctx, d)
 	matrix := []map[string]interface{}{
 		{"region": defaultIBMRegion},
 	}
@@ -81,15 +141,22 @@ func BuildRegionList(_ context.Context, d *plugin.QueryData) []map[string]interf
 	return matrix
 }
 
-// Return invalid regions from a region list
-func getInvalidRegions(regions []string) []string {
-	invalidRegions := []string{}
-	for _, region := range regions {
-		if !slices.Contains(Regions(), region) {
-			invalidRegions = append(invalidRegions, region)
+// Return the region patterns which match none of the valid regions
+func getInvalidRegionPatterns(patterns []string, validRegions []string) []string {
+	invalidPatterns := []string{}
+	for _, pattern := range patterns {
+		matched := false
+		for _, validRegion := range validRegions {
+			if ok, _ := path.Match(pattern, validRegion); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			invalidPatterns = append(invalidPatterns, pattern)
 		}
 	}
-	return invalidRegions
+	return invalidPatterns
 }
 
 // Transform used to get the region column
@@ -104,6 +171,12 @@ func getServiceInstanceID(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	return instanceID, nil
 }
 
+// Transform used to get the service type of a service instance, e.g. the kms_type column
+func getServiceType(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	serviceType := d.EqualsQualString("service_type")
+	return serviceType, nil
+}
+
 // Transform used to get the instance_crn column
 func getServiceInstanceCRN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	instanceCRN := d.EqualsQualString("instance_crn")
@@ -112,67 +185,39 @@ func getServiceInstanceCRN(ctx context.Context, d *plugin.QueryData, h *plugin.H
 
 // GetDefaultIBMRegion returns the default region for IBM account
 // if not set by Env variable
-func GetDefaultIBMRegion(d *plugin.QueryData) string {
+func GetDefaultIBMRegion(ctx context.Context, d *plugin.QueryData) string {
 	// have we already created and cached the service?
 	serviceCacheKey := "GetDefaultIBMRegion"
 	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
 		return cachedData.(string)
 	}
-	allIBMRegions := Regions()
-
-	// get ibm config info
-	ibmConfig := GetConfig(d.Connection)
-
-	var regions []string
-	var region string
+	allIBMRegions := listAvailableRegions(ctx, d)
+	region := configuredRegion(d)
 
-	if ibmConfig.Regions != nil {
-		regions = ibmConfig.Regions
-		region = regions[0]
-	} else {
-		// Fetch regions from environment variables
-		if os.Getenv("IBMCLOUD_REGION") != "" {
-			region = os.Getenv("IBMCLOUD_REGION")
-		}
-		if os.Getenv("IC_REGION") != "" {
-			region = os.Getenv("IC_REGION")
-		}
-
-		if region != "" {
-			regions = []string{region}
-		}
-
-		// https://registry.terraform.io/providers/IBM-Cloud/ibm/latest/docs#region
-		if !slices.Contains(allIBMRegions, region) {
-			regions = []string{"us-south"}
-		}
+	// Invalid region patterns are reported by BuildRegionList, here we only
+	// fall back to the default region
+	// https://registry.terraform.io/providers/IBM-Cloud/ibm/latest/docs#region
+	if !slices.Contains(allIBMRegions, region) {
+		region = defaultRegion
 	}
 
-	validPatterns := []string{}
-	invalidPatterns := []string{}
-	for _, namePattern := range regions {
-		validRegions := []string{}
-		for _, validRegion := range allIBMRegions {
-			if ok, _ := path.Match(namePattern, validRegion); ok {
-				validRegions = append(validRegions, validRegion)
-			}
-		}
-		if len(validRegions) == 0 {
-			invalidPatterns = append(invalidPatterns, namePattern)
-		} else {
-			validPatterns = append(validPatterns, namePattern)
-		}
-	}
+	d.ConnectionManager.Cache.Set(serviceCacheKey, region)
+	return region
+}
 
-	if len(validPatterns) == 0 {
-		panic("\nconnection config have invalid \"regions\": " + strings.Join(invalidPatterns, ", ") + ". Edit your connection configuration file and then restart Steampipe")
+// configuredRegion returns the first region of the connection config, or the
+// region of the environment, without checking that it is available
+func configuredRegion(d *plugin.QueryData) string {
+	ibmConfig := GetConfig(d.Connection)
+	if len(ibmConfig.Regions) > 0 {
+		return ibmConfig.Regions[0]
 	}
 
-	if !slices.Contains(allIBMRegions, region) {
-		region = "us-south"
+	// Fetch regions from environment variables
+	region := os.Getenv("IBMCLOUD_REGION")
+	if os.Getenv("IC_REGION") != "" {
+		region = os.Getenv("IC_REGION")
 	}
-
-	d.ConnectionManager.Cache.Set(serviceCacheKey, region)
 	return region
 }
 
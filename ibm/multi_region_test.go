@@ -0,0 +1,72 @@
+package ibm
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// regionalConfig points the VPC endpoint of the connection at a path per
+// region, e.g. /eu-de/v1, and configures the given regions
+func regionalConfig(cloud *fakeCloud, regions string) string {
+	config := strings.Replace(cloud.config(), cloud.VPC.URL+"/v1", cloud.VPC.URL+"/{region}/v1", 1)
+	return config + "\nregions = " + regions + "\n"
+}
+
+func TestListAvailableRegions(t *testing.T) {
+	t.Run("configured region", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.VPC.fixture("GET /eu-de/v1/regions", "vpc/regions.json")
+		cloud.VPC.fixture("GET /{region}/v1/vpcs", "vpc/vpcs.json")
+		conn := newTestConnection(t, regionalConfig(cloud, `["eu-de", "us-south"]`))
+
+		// The regions are discovered from the first configured region
+		rows := conn.mustQuery("ibm_is_vpc", nil, "id", "region")
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, want one per region", len(rows))
+		}
+		if got := cloud.VPC.requestsTo("/eu-de/v1/regions"); len(got) != 1 {
+			t.Errorf("got %d region requests to eu-de, want 1", len(got))
+		}
+	})
+
+	t.Run("no available region", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.VPC.handle("GET /us-south/v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"regions": []interface{}{}})
+		})
+		cloud.VPC.fixture("GET /{region}/v1/vpcs", "vpc/vpcs.json")
+		conn := newTestConnection(t, regionalConfig(cloud, `["jp-tok"]`))
+
+		// The configured region is checked against the static list instead
+		rows := conn.mustQuery("ibm_is_vpc", nil, "id", "region")
+		if len(rows) != 1 || rows[0]["region"] != "jp-tok" {
+			t.Fatalf("got rows %v, want the VPC of jp-tok", rows)
+		}
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.VPC.handle("GET /us-south/v1/regions", unavailableHandler(100, nil))
+		cloud.VPC.fixture("GET /{region}/v1/vpcs", "vpc/vpcs.json")
+		cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/service_instances.json")
+		cloud.COS.handle("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write(readFixture(t, "cos/list_buckets_2.xml"))
+		})
+		conn := newTestConnection(t, regionalConfig(cloud, `["*"]`))
+
+		// The static list is used for every region
+		rows := conn.mustQuery("ibm_is_vpc", nil, "id", "region")
+		if len(rows) != len(Regions()) {
+			t.Errorf("got %d rows, want one per static region", len(rows))
+		}
+
+		// The static list is cached too, so the default region of the
+		// bucket table does not discover the regions again
+		conn.mustQuery("ibm_cos_bucket", nil, "name", "region")
+		if got := cloud.VPC.requestsTo("/us-south/v1/regions"); len(got) != 1 {
+			t.Errorf("got %d region requests, want 1", len(got))
+		}
+	})
+}
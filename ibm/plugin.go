@@ -19,7 +19,9 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
-		DefaultTransform: transform.FromGo().NullIfZero(),
+		ConnectionConfigChangedFunc: connectionConfigChanged,
+		DefaultTransform:            transform.FromGo().NullIfZero(),
+		RateLimiters:                rateLimiters(),
 		TableMap: map[string]*plugin.Table{
 			"ibm_account":                         tableIbmAccount(ctx),
 			"ibm_certificate_manager_certificate": tableIbmCertificateManagerCertificate(ctx),
@@ -42,9 +44,20 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"ibm_is_subnet":                       tableIbmIsSubnet(ctx),
 			"ibm_is_volume":                       tableIbmIsVolume(ctx),
 			"ibm_is_vpc":                          tableIbmIsVpc(ctx),
+			"ibm_kms_instance":                    tableIbmKmsInstance(ctx),
+			"ibm_kms_instance_policy":             tableIbmKmsInstancePolicy(ctx),
 			"ibm_kms_key":                         tableIbmKmsKey(ctx),
+			"ibm_kms_key_policy":                  tableIbmKmsKeyPolicy(ctx),
+			"ibm_kms_key_registration":            tableIbmKmsKeyRegistration(ctx),
 			"ibm_kms_key_ring":                    tableIbmKmsKeyRing(ctx),
+			"ibm_kms_key_version":                 tableIbmKmsKeyVersion(ctx),
+			"ibm_resource_alias":                  tableIbmResourceAlias(ctx),
+			"ibm_resource_binding":                tableIbmResourceBinding(ctx),
 			"ibm_resource_group":                  tableIbmResourceGroup(ctx),
+			"ibm_resource_instance":               tableIbmResourceInstance(ctx),
+			"ibm_resource_key":                    tableIbmResourceKey(ctx),
+			"ibm_resource_reclamation":            tableIbmResourceReclamation(ctx),
+			"ibm_tag":                             tableIbmTag(ctx),
 		},
 	}
 	return p
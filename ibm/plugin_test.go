@@ -0,0 +1,240 @@
+package ibm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// testConnection runs the plugin in process, like the Steampipe CLI does over
+// GRPC, so queries run the real matrix, hydrate and transform functions
+type testConnection struct {
+	t      *testing.T
+	server *grpc.PluginServer
+	name   string
+}
+
+var (
+	testConnectionCount atomic.Int64
+	testQueryCount      atomic.Int64
+)
+
+// newTestConnection starts a plugin with a single connection. Every
+// connection has its own plugin, so no clients or results are cached across tests.
+func newTestConnection(t *testing.T, config string) *testConnection {
+	t.Helper()
+
+	c := &testConnection{
+		t:      t,
+		server: plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin}),
+		name:   fmt.Sprintf("ibm_test_%d", testConnectionCount.Add(1)),
+	}
+
+	_, err := c.server.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+		Configs: []*proto.ConnectionConfig{
+			{
+				Connection:      c.name,
+				Plugin:          "hub.steampipe.io/plugins/turbot/ibm@latest",
+				PluginShortName: "ibm",
+				Config:          config,
+			},
+		},
+		MaxCacheSizeMb: 16,
+	})
+	if err != nil {
+		t.Fatalf("failed to set connection config: %v", err)
+	}
+
+	return c
+}
+
+// updateConfig changes the config of the connection, like the Steampipe CLI
+// does when the connection configuration file is edited
+func (c *testConnection) updateConfig(config string) {
+	c.t.Helper()
+
+	_, err := c.server.UpdateConnectionConfigs(&proto.UpdateConnectionConfigsRequest{
+		Changed: []*proto.ConnectionConfig{
+			{
+				Connection:      c.name,
+				Plugin:          "hub.steampipe.io/plugins/turbot/ibm@latest",
+				PluginShortName: "ibm",
+				Config:          config,
+			},
+		},
+	})
+	if err != nil {
+		c.t.Fatalf("failed to update connection config: %v", err)
+	}
+}
+
+// query returns the rows of a table, with the given equality quals. Every
+// column of the table is returned if no columns are given.
+func (c *testConnection) query(table string, quals map[string]interface{}, columns ...string) ([]map[string]interface{}, error) {
+	c.t.Helper()
+
+	if len(columns) == 0 {
+		for _, column := range Plugin(context.Background()).TableMap[table].Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	qualMap := map[string]*proto.Quals{}
+	for column, value := range quals {
+		qualMap[column] = &proto.Quals{
+			Quals: []*proto.Qual{
+				{
+					FieldName: column,
+					Operator:  &proto.Qual_StringValue{StringValue: "="},
+					Value:     testQualValue(c.t, value),
+				},
+			},
+		}
+	}
+
+	stream := &testRowStream{ctx: context.Background()}
+	err := c.server.Execute(&proto.ExecuteRequest{
+		Table: table,
+		QueryContext: &proto.QueryContext{
+			Columns: columns,
+			Quals:   qualMap,
+		},
+		Connection: c.name,
+		CallId:     fmt.Sprintf("%s_%s_%d", c.name, table, testQueryCount.Add(1)),
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{
+			c.name: {CacheEnabled: false},
+		},
+	}, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]interface{}, 0, len(stream.rows))
+	for _, row := range stream.rows {
+		values := map[string]interface{}{}
+		for column, value := range row.Columns {
+			values[column] = testColumnValue(c.t, value)
+		}
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// mustQuery returns the rows of a table, and fails the test if the query fails
+func (c *testConnection) mustQuery(table string, quals map[string]interface{}, columns ...string) []map[string]interface{} {
+	c.t.Helper()
+
+	rows, err := c.query(table, quals, columns...)
+	if err != nil {
+		c.t.Fatalf("select from %s failed: %v", table, err)
+	}
+	return rows
+}
+
+// testRowStream collects the rows streamed by the plugin. It only implements
+// the methods of the GRPC stream which are used by the plugin.
+type testRowStream struct {
+	proto.WrapperPlugin_ExecuteServer
+
+	ctx  context.Context
+	mu   sync.Mutex
+	rows []*proto.Row
+}
+
+func (s *testRowStream) Send(resp *proto.ExecuteResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resp != nil && resp.Row != nil {
+		s.rows = append(s.rows, resp.Row)
+	}
+	return nil
+}
+
+func (s *testRowStream) Context() context.Context {
+	return s.ctx
+}
+
+func testQualValue(t *testing.T, value interface{}) *proto.QualValue {
+	t.Helper()
+
+	switch v := value.(type) {
+	case string:
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}}
+	case bool:
+		return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: v}}
+	case int:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: int64(v)}}
+	}
+	t.Fatalf("unsupported qual value %T", value)
+	return nil
+}
+
+// testColumnValue converts a column value to a Go value. JSON columns are
+// decoded, and timestamps are returned in RFC 3339 format.
+func testColumnValue(t *testing.T, column *proto.Column) interface{} {
+	t.Helper()
+
+	switch v := column.Value.(type) {
+	case *proto.Column_NullValue:
+		return nil
+	case *proto.Column_StringValue:
+		return v.StringValue
+	case *proto.Column_BoolValue:
+		return v.BoolValue
+	case *proto.Column_IntValue:
+		return v.IntValue
+	case *proto.Column_DoubleValue:
+		return v.DoubleValue
+	case *proto.Column_TimestampValue:
+		return v.TimestampValue.AsTime().Format("2006-01-02T15:04:05Z07:00")
+	case *proto.Column_JsonValue:
+		var value interface{}
+		if err := json.Unmarshal(v.JsonValue, &value); err != nil {
+			t.Fatalf("invalid JSON column value: %v", err)
+		}
+		return value
+	case *proto.Column_IpAddrValue:
+		return v.IpAddrValue
+	case *proto.Column_CidrRangeValue:
+		return v.CidrRangeValue
+	}
+	t.Fatalf("unsupported column value %T", column.Value)
+	return nil
+}
+
+// assertColumns checks the values of some columns of a row
+func assertColumns(t *testing.T, row map[string]interface{}, expected map[string]interface{}) {
+	t.Helper()
+
+	for column, value := range expected {
+		got, _ := json.Marshal(row[column])
+		want, _ := json.Marshal(value)
+		if string(got) != string(want) {
+			t.Errorf("column %s = %s, want %s", column, got, want)
+		}
+	}
+}
+
+// rowsByColumn returns the rows keyed by the value of a column
+func rowsByColumn(t *testing.T, rows []map[string]interface{}, column string) map[string]map[string]interface{} {
+	t.Helper()
+
+	keyed := map[string]map[string]interface{}{}
+	for _, row := range rows {
+		key, ok := row[column].(string)
+		if !ok {
+			t.Fatalf("column %s is not a string: %v", column, row[column])
+		}
+		keyed[key] = row
+	}
+	return keyed
+}
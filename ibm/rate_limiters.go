@@ -0,0 +1,218 @@
+package ibm
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/rate_limiter"
+	"golang.org/x/time/rate"
+)
+
+// Services used to scope the rate limiters, set as the "service" tag of every
+// hydrate function. The services with a configurable endpoint share the name
+// of their endpoint.
+const (
+	rateLimiterServiceAccount            = "account"
+	rateLimiterServiceCertificateManager = "certificate_manager"
+)
+
+// Services which can be limited in the rate_limits block of the connection config
+var rateLimitServices = []string{
+	endpointIAM,
+	endpointVPC,
+	endpointKMS,
+	endpointCOS,
+	endpointCIS,
+	endpointResourceController,
+	endpointResourceManager,
+	endpointGlobalTagging,
+	endpointGlobalSearch,
+	rateLimiterServiceAccount,
+	rateLimiterServiceCertificateManager,
+}
+
+// serviceTags returns the rate limiter tags of a hydrate function which calls the given service
+func serviceTags(service string) map[string]string {
+	return map[string]string{"service": service}
+}
+
+// rateLimiters returns the default rate limiters of the plugin. The limits can
+// be tuned with a limiter block of the same name in the plugin config, e.g.
+//
+//	plugin "ibm" {
+//	  limiter "ibm_global_tagging" {
+//	    bucket_size = 5
+//	    fill_rate   = 5
+//	    scope       = ["connection", "service"]
+//	    where       = "service = 'global_tagging'"
+//	  }
+//	}
+func rateLimiters() []*rate_limiter.Definition {
+	return []*rate_limiter.Definition{
+		// Regional services
+		{
+			Name:       "ibm_vpc",
+			FillRate:   20,
+			BucketSize: 40,
+			Scope:      []string{"connection", "region", "service"},
+			Where:      "service = 'vpc'",
+		},
+		{
+			Name:       "ibm_kms",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "region", "service"},
+			Where:      "service = 'kms'",
+		},
+		{
+			Name:       "ibm_cos",
+			FillRate:   20,
+			BucketSize: 40,
+			Scope:      []string{"connection", "region", "service"},
+			Where:      "service = 'cos'",
+		},
+		{
+			Name:       "ibm_certificate_manager",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "region", "service"},
+			Where:      "service = 'certificate_manager'",
+		},
+		// Global services
+		{
+			Name:       "ibm_account",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'account'",
+		},
+		{
+			Name:       "ibm_cis",
+			FillRate:   4,
+			BucketSize: 8,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'cis'",
+		},
+		{
+			Name:       "ibm_global_search",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'global_search'",
+		},
+		{
+			Name:       "ibm_global_tagging",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'global_tagging'",
+		},
+		{
+			Name:       "ibm_iam",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'iam'",
+		},
+		{
+			Name:       "ibm_resource_controller",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'resource_controller'",
+		},
+		{
+			Name:       "ibm_resource_manager",
+			FillRate:   10,
+			BucketSize: 20,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'resource_manager'",
+		},
+	}
+}
+
+// get returns the request rate limit of a service, if any
+func (r *ibmRateLimitConfig) get(service string) *int {
+	if r == nil {
+		return nil
+	}
+	switch service {
+	case endpointIAM:
+		return r.IAM
+	case endpointVPC:
+		return r.VPC
+	case endpointKMS:
+		return r.KMS
+	case endpointCOS:
+		return r.COS
+	case endpointCIS:
+		return r.CIS
+	case endpointResourceController:
+		return r.ResourceController
+	case endpointResourceManager:
+		return r.ResourceManager
+	case endpointGlobalTagging:
+		return r.GlobalTagging
+	case endpointGlobalSearch:
+		return r.GlobalSearch
+	case rateLimiterServiceAccount:
+		return r.Account
+	case rateLimiterServiceCertificateManager:
+		return r.CertificateManager
+	}
+	return nil
+}
+
+// requestRateLimiters holds the limiters of the rate_limits connection argument.
+// They are kept outside of the connection cache, which may evict the clients,
+// so that every client of a connection, service and region shares one limiter.
+var requestRateLimiters sync.Map
+
+// requestRateLimiter returns the limiter of the requests to a service in a
+// region, or nil if the connection does not limit the service. Global services
+// pass an empty region.
+func requestRateLimiter(d *plugin.QueryData, service string, region string) *rate.Limiter {
+	if d.Connection == nil {
+		return nil
+	}
+	limit := GetConfig(d.Connection).RateLimits.get(service)
+	if limit == nil {
+		return nil
+	}
+	// The limit is part of the key, so a changed config gets a new limiter
+	key := fmt.Sprintf("%s/%s/%s/%d", d.Connection.Name, service, region, *limit)
+	limiter, _ := requestRateLimiters.LoadOrStore(key, rate.NewLimiter(rate.Limit(*limit), *limit))
+	return limiter.(*rate.Limiter)
+}
+
+// rateLimitTransport waits for the limiter of the connection before every
+// request, including every retry of a request
+type rateLimitTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+// newRateLimitTransport wraps a transport with the request rate limit of a
+// service, if the connection config sets one
+func newRateLimitTransport(d *plugin.QueryData, service string, region string, transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	limiter := requestRateLimiter(d, service, region)
+	if limiter == nil {
+		return transport
+	}
+	return &rateLimitTransport{
+		limiter:   limiter,
+		transport: transport,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
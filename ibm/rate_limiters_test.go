@@ -0,0 +1,60 @@
+package ibm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestRateLimiters checks that every service which a hydrate function is
+// tagged with is limited by one of the default rate limiters
+func TestRateLimiters(t *testing.T) {
+	limited := map[string]bool{}
+	for _, limiter := range rateLimiters() {
+		limited[limiter.Where] = true
+	}
+
+	check := func(table string, tags map[string]string) {
+		service, ok := tags["service"]
+		if !ok {
+			return
+		}
+		if !limited[fmt.Sprintf("service = '%s'", service)] {
+			t.Errorf("table %s: no rate limiter for service %q", table, service)
+		}
+	}
+
+	for name, table := range Plugin(context.Background()).TableMap {
+		if table.List != nil {
+			check(name, table.List.Tags)
+		}
+		if table.Get != nil {
+			check(name, table.Get.Tags)
+		}
+		for _, config := range table.HydrateConfig {
+			check(name, config.Tags)
+		}
+	}
+}
+
+func TestRateLimitsConfig(t *testing.T) {
+	// Both pages of users are requested from the IAM user management API
+	query := func(config string) time.Duration {
+		cloud := newFakeUserCloud(t)
+		conn := newTestConnection(t, cloud.config()+config)
+		start := time.Now()
+		if rows := conn.mustQuery("ibm_iam_user", nil, "iam_id"); len(rows) != 2 {
+			t.Fatalf("got %d rows, want 2", len(rows))
+		}
+		return time.Since(start)
+	}
+
+	if elapsed := query(""); elapsed > 500*time.Millisecond {
+		t.Errorf("unlimited query took %v", elapsed)
+	}
+	// A limit of one request per second delays the second page
+	if elapsed := query("\nrate_limits {\n  iam = 1\n}\n"); elapsed < 900*time.Millisecond {
+		t.Errorf("limited query took %v, want at least a second", elapsed)
+	}
+}
@@ -0,0 +1,126 @@
+package ibm
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/aws/client"
+	"github.com/IBM/ibm-cos-sdk-go/aws/request"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/go-hclog"
+	rhttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// Defaults match the retry policy of the IBM Go SDKs
+	defaultMaxRetries = 4
+	defaultMaxBackoff = 30
+)
+
+// configMaxRetries returns the maximum number of retries for a request, zero disables retries
+func configMaxRetries(ibmConfig ibmConfig) int {
+	if ibmConfig.MaxRetries != nil {
+		return *ibmConfig.MaxRetries
+	}
+	return defaultMaxRetries
+}
+
+// configMaxBackoff returns the maximum time to wait between two retries
+func configMaxBackoff(ibmConfig ibmConfig) time.Duration {
+	if ibmConfig.MaxBackoff != nil {
+		return time.Duration(*ibmConfig.MaxBackoff) * time.Second
+	}
+	return defaultMaxBackoff * time.Second
+}
+
+// enableRetries applies the connection retry policy and request rate limit to a
+// go-sdk-core based service client, and logs every response which may be retried
+func enableRetries(ctx context.Context, d *plugin.QueryData, service *core.BaseService, serviceName string, region string) {
+	ibmConfig := GetConfig(d.Connection)
+
+	// The retryable client wraps this transport, so every attempt is limited
+	httpClient := service.GetHTTPClient()
+	httpClient.Transport = newRateLimitTransport(d, serviceName, region, newRetryLoggingTransport(ctx, httpClient.Transport))
+
+	maxRetries := configMaxRetries(ibmConfig)
+	if maxRetries == 0 {
+		service.DisableRetries()
+		return
+	}
+	service.EnableRetries(maxRetries, configMaxBackoff(ibmConfig))
+}
+
+// cosRetryConfig applies the connection retry policy and request rate limit to
+// a COS client config
+func cosRetryConfig(ctx context.Context, d *plugin.QueryData, region string, conf *aws.Config) *aws.Config {
+	ibmConfig := GetConfig(d.Connection)
+	maxBackoff := configMaxBackoff(ibmConfig)
+
+	conf.WithHTTPClient(&http.Client{
+		Transport: newRateLimitTransport(d, endpointCOS, region, newRetryLoggingTransport(ctx, nil)),
+	})
+	return request.WithRetryer(conf, client.DefaultRetryer{
+		NumMaxRetries:    configMaxRetries(ibmConfig),
+		MaxRetryDelay:    maxBackoff,
+		MaxThrottleDelay: maxBackoff,
+	})
+}
+
+func init() {
+	// keyprotect-go-client reads its retry policy from package variables, so
+	// there is no per client policy. The variables are shared by every
+	// connection of the plugin process, but no other code of the process uses
+	// the package. The KMS clients retry in their transport instead, see
+	// newKmsRetryTransport, so the client must not retry again.
+	kp.RetryMax = 0
+}
+
+// newKmsRetryTransport returns a transport which applies the connection retry
+// policy to the requests of a keyprotect client. It retries the same
+// responses as the go-sdk-core based clients, i.e. rate limited requests and
+// server errors other than 501 Not Implemented, and honours Retry-After.
+func newKmsRetryTransport(d *plugin.QueryData, transport http.RoundTripper) http.RoundTripper {
+	ibmConfig := GetConfig(d.Connection)
+
+	client := core.NewRetryableClientWithHTTPClient(&http.Client{Transport: transport})
+	client.Logger = nil
+	client.RetryMax = configMaxRetries(ibmConfig)
+	client.RetryWaitMax = configMaxBackoff(ibmConfig)
+
+	return &rhttp.RoundTripper{Client: client}
+}
+
+// retryLoggingTransport logs the responses which are retried by the service
+// clients, i.e. rate limited requests and server errors
+type retryLoggingTransport struct {
+	logger    hclog.Logger
+	transport http.RoundTripper
+}
+
+func newRetryLoggingTransport(ctx context.Context, transport http.RoundTripper) *retryLoggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	// Clients are cached per connection, so keep the logger rather than the
+	// context of the query which created the client
+	return &retryLoggingTransport{
+		logger:    plugin.Logger(ctx),
+		transport: transport,
+	}
+}
+
+func (t *retryLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		t.logger.Warn("retryLoggingTransport", "method", req.Method, "url", req.URL.Redacted(), "retryable_error", err)
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented) {
+		t.logger.Warn("retryLoggingTransport", "method", req.Method, "url", req.URL.Redacted(), "retryable_status", resp.StatusCode)
+	}
+	return resp, nil
+}
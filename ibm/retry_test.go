@@ -0,0 +1,111 @@
+package ibm
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	kp "github.com/IBM/keyprotect-go-client"
+)
+
+// unavailableHandler fails the first requests with a server error, which may
+// be retried immediately, and then serves the requests with handler
+func unavailableHandler(failures int64, handler http.HandlerFunc) http.HandlerFunc {
+	var count atomic.Int64
+	return func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+				"resources": []map[string]string{{"errorMsg": "Service Unavailable"}},
+			})
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// TestKmsRetryPolicy checks that every connection retries its Key Protect
+// requests with its own retry policy, and that requests are not retried by
+// both the plugin and keyprotect-go-client
+func TestKmsRetryPolicy(t *testing.T) {
+	keys := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "kms/keys.json"))
+	}
+
+	tests := []struct {
+		name       string
+		maxRetries int
+		failures   int64
+		wantErr    bool
+		wantCalls  int
+	}{
+		{name: "retries disabled", maxRetries: 0, failures: 1, wantErr: true, wantCalls: 1},
+		{name: "retried until success", maxRetries: 2, failures: 2, wantErr: false, wantCalls: 3},
+		{name: "retries exhausted", maxRetries: 2, failures: 10, wantErr: true, wantCalls: 3},
+	}
+
+	// The connections run in the same process, so the policy of the first
+	// connection must not apply to the others
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloud := newFakeCloud(t)
+			cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/resource_instances.json")
+			cloud.KMS.handle("GET /api/v2/keys", unavailableHandler(tt.failures, keys))
+			conn := newTestConnection(t, cloud.configWithRetries(tt.maxRetries))
+
+			_, err := conn.query("ibm_kms_key", nil, "id")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if got := len(cloud.KMS.requestsTo("/api/v2/keys")); got != tt.wantCalls {
+				t.Errorf("got %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// TestKmsGetRetryPolicy checks that the requests to the Key Protect APIs which
+// keyprotect-go-client does not support are retried with the connection policy
+func TestKmsGetRetryPolicy(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	cloud.KMS.handle("GET /api/v2/keys/registrations", unavailableHandler(1, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "kms/registrations.json"))
+	}))
+	conn := newTestConnection(t, cloud.configWithRetries(1))
+
+	rows := conn.mustQuery("ibm_kms_key_registration", nil, "key_id")
+	if len(rows) != 1 {
+		t.Errorf("got %d rows, want 1", len(rows))
+	}
+	if got := len(cloud.KMS.requestsTo("/api/v2/keys/registrations")); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
+
+// TestKmsClientRetriesDisabled checks the process wide override of the
+// keyprotect-go-client retry policy, which every connection relies on
+func TestKmsClientRetriesDisabled(t *testing.T) {
+	if kp.RetryMax != 0 {
+		t.Errorf("got kp.RetryMax %d, want 0", kp.RetryMax)
+	}
+}
+
+// TestKmsRetryNotImplemented checks that Key Protect requests are retried
+// with the policy of the other service clients, which does not retry APIs
+// that an instance does not implement
+func TestKmsRetryNotImplemented(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	cloud.KMS.handle("GET /api/v2/keys/registrations", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusNotImplemented, map[string]interface{}{
+			"resources": []map[string]string{{"errorMsg": "Not Implemented"}},
+		})
+	})
+	conn := newTestConnection(t, cloud.configWithRetries(2))
+
+	conn.query("ibm_kms_key_registration", nil, "key_id")
+	if got := len(cloud.KMS.requestsTo("/api/v2/keys/registrations")); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
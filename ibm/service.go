@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/ibm-cos-sdk-go/aws"
-	"github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials"
 	"github.com/IBM/ibm-cos-sdk-go/aws/session"
 	"github.com/IBM/ibm-cos-sdk-go/service/s3"
 	kp "github.com/IBM/keyprotect-go-client"
@@ -14,6 +13,7 @@ import (
 	"github.com/IBM/networking-go-sdk/globalloadbalancerv1"
 	"github.com/IBM/networking-go-sdk/zonessettingsv1"
 	"github.com/IBM/networking-go-sdk/zonesv1"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
 	"github.com/IBM/platform-services-go-sdk/globaltaggingv1"
 	"github.com/IBM/platform-services-go-sdk/iamaccessgroupsv2"
 	"github.com/IBM/platform-services-go-sdk/iamidentityv1"
@@ -24,28 +24,52 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
-// kmsService return the service for IBM KMS service
-func kmsService(ctx context.Context, d *plugin.QueryData) (*kp.Client, error) {
-	region := d.EqualsQualString("region")
+// kmsService return the service for IBM KMS service, of a Key Protect or a
+// Hyper Protect Crypto Services instance. The client is bound to a single
+// instance, as it sends the instance ID with every request.
+func kmsService(ctx context.Context, d *plugin.QueryData, kmsType string, region string, instanceID string) (*kp.Client, error) {
+	if region == "" || instanceID == "" {
+		return nil, fmt.Errorf("region and instance ID must be passed kmsService")
+	}
 
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "ibm_kms"
+	cacheKey := "ibm_kms_" + region + "_" + instanceID
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*kp.Client), nil
 	}
 
-	// Create region endpoint
-	endpoint := fmt.Sprintf("https://%s.kms.cloud.ibm.com", region)
-	apiKey, err := configApiKey(ctx, d)
+	// Create region endpoint, or look up the endpoint of the HPCS instance
+	endpoint := serviceEndpoint(d, endpointKMS, region)
+	if kmsType == kmsTypeHPCS {
+		hpcsEndpoint, err := getHpcsEndpoint(ctx, d, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = hpcsEndpoint
+	}
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
+	accessToken, err := getAuthenticatorToken(authenticator)
+	if err != nil {
+		return nil, err
+	}
+	// The transport authenticates every request, the initial token only stops
+	// the client from requesting its own token with an API key
 	opts := kp.ClientConfig{
-		BaseURL:  endpoint,
-		APIKey:   apiKey,
-		TokenURL: kp.DefaultTokenURL,
+		BaseURL:       endpoint,
+		Authorization: "Bearer " + accessToken,
+		InstanceID:    instanceID,
+	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	transport := &authenticatorTransport{
+		authenticator: authenticator,
+		transport: &kmsRequestTransport{
+			transport: newKmsRetryTransport(d, newRateLimitTransport(d, endpointKMS, region, newRetryLoggingTransport(ctx, kp.DefaultTransport()))),
+		},
 	}
-	service, err := kp.New(opts, kp.DefaultTransport())
+	service, err := kp.New(opts, transport)
 	if err != nil {
 		return nil, err
 	}
@@ -56,12 +80,17 @@ func kmsService(ctx context.Context, d *plugin.QueryData) (*kp.Client, error) {
 
 // vpcService returns the service for IBM VPC Infrastructure service
 func vpcService(ctx context.Context, d *plugin.QueryData, region string) (*vpcv1.VpcV1, error) {
+	// Raise any error from building the region matrix
+	if err := getMatrixError(d); err != nil {
+		return nil, err
+	}
+
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed vpcService")
 	}
 
 	// Create region endpoint
-	endpoint := fmt.Sprintf("https://%s.iaas.cloud.ibm.com/v1", region)
+	endpoint := serviceEndpoint(d, endpointVPC, region)
 
 	// Load connection from cache, which preserves throttling protection etc
 	cacheKey := endpoint
@@ -69,23 +98,24 @@ func vpcService(ctx context.Context, d *plugin.QueryData, region string) (*vpcv1
 		return cachedData.(*vpcv1.VpcV1), nil
 	}
 
-	// Fetch API key from config
-	apiKey, err := configApiKey(ctx, d)
+	// Fetch authenticator from config
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
-	// Instantiate the service with an API key based IAM authenticator
+	// Instantiate the service with the connection authenticator
 	service, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{
-		URL: endpoint,
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		URL:           endpoint,
+		Authenticator: authenticator,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointVPC, region)
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 
@@ -93,33 +123,36 @@ func vpcService(ctx context.Context, d *plugin.QueryData, region string) (*vpcv1
 }
 
 // cisZoneService returns the service for IBM CIS Zone service
-func cisZoneService(ctx context.Context, d *plugin.QueryData) (*zonesv1.ZonesV1, error) {
-	serviceInstanceID := "crn:v1:bluemix:public:internet-svcs:global:a/76aa4877fab6436db86f121f62faf221:3e5dc1e0-3aea-4699-986e-e3b8f117c51d::"
-	endpoint := "https://api.cis.cloud.ibm.com"
+func cisZoneService(ctx context.Context, d *plugin.QueryData, instanceCRN string) (*zonesv1.ZonesV1, error) {
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("instance CRN must be passed cisZoneService")
+	}
+	endpoint := serviceEndpoint(d, endpointCIS, "")
 
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "cisZone"
+	cacheKey := "cisZone" + instanceCRN
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*zonesv1.ZonesV1), nil
 	}
 
-	// Fetch API key from config
-	apiKey, err := configApiKey(ctx, d)
+	// Fetch authenticator from config
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	// Instantiate the service with an API key based IAM authenticator
+	// Instantiate the service with the connection authenticator
 	service, err := zonesv1.NewZonesV1(&zonesv1.ZonesV1Options{
-		Crn: &serviceInstanceID,
-		URL: endpoint,
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		Crn:           &instanceCRN,
+		URL:           endpoint,
+		Authenticator: authenticator,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointCIS, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 
@@ -127,35 +160,38 @@ func cisZoneService(ctx context.Context, d *plugin.QueryData) (*zonesv1.ZonesV1,
 }
 
 // cisZoneSettingService returns the service for IBM CIS Zone Setting service
-func cisZoneSettingService(ctx context.Context, d *plugin.QueryData, zoneId string) (*zonessettingsv1.ZonesSettingsV1, error) {
-	serviceInstanceID := "crn:v1:bluemix:public:internet-svcs:global:a/76aa4877fab6436db86f121f62faf221:3e5dc1e0-3aea-4699-986e-e3b8f117c51d::"
-	endpoint := "https://api.cis.cloud.ibm.com"
+func cisZoneSettingService(ctx context.Context, d *plugin.QueryData, instanceCRN string, zoneId string) (*zonessettingsv1.ZonesSettingsV1, error) {
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("instance CRN must be passed cisZoneSettingService")
+	}
+	endpoint := serviceEndpoint(d, endpointCIS, "")
 
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "cisZoneSetting"
+	cacheKey := "cisZoneSetting" + instanceCRN + zoneId
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*zonessettingsv1.ZonesSettingsV1), nil
 	}
 
-	// Fetch API key from config
-	apiKey, err := configApiKey(ctx, d)
+	// Fetch authenticator from config
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	// Instantiate the service with an API key based IAM authenticator
+	// Instantiate the service with the connection authenticator
 	service, err := zonessettingsv1.NewZonesSettingsV1(&zonessettingsv1.ZonesSettingsV1Options{
-		Crn:            &serviceInstanceID,
+		Crn:            &instanceCRN,
 		ZoneIdentifier: &zoneId,
 		URL:            endpoint,
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		Authenticator:  authenticator,
 	})
 	if err != nil {
 		plugin.Logger(ctx).Error("getTlsMinimumVersion", "zoneId", zoneId)
 		return nil, err
 	}
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointCIS, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 
@@ -163,69 +199,102 @@ func cisZoneSettingService(ctx context.Context, d *plugin.QueryData, zoneId stri
 }
 
 // cisGlobalLoadBalancerService returns the service for IBM CIS Global Load Balancer service
-func cisGlobalLoadBalancerService(ctx context.Context, d *plugin.QueryData, zoneId string) (*globalloadbalancerv1.GlobalLoadBalancerV1, error) {
-	serviceInstanceID := "crn:v1:bluemix:public:internet-svcs:global:a/76aa4877fab6436db86f121f62faf221:3e5dc1e0-3aea-4699-986e-e3b8f117c51d::"
-	endpoint := "https://api.cis.cloud.ibm.com"
+func cisGlobalLoadBalancerService(ctx context.Context, d *plugin.QueryData, instanceCRN string, zoneId string) (*globalloadbalancerv1.GlobalLoadBalancerV1, error) {
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("instance CRN must be passed cisGlobalLoadBalancerService")
+	}
+	endpoint := serviceEndpoint(d, endpointCIS, "")
 
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "cisGlobalLoadBalancer"
+	cacheKey := "cisGlobalLoadBalancer" + instanceCRN + zoneId
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*globalloadbalancerv1.GlobalLoadBalancerV1), nil
 	}
 
-	// Fetch API key from config
-	apiKey, err := configApiKey(ctx, d)
+	// Fetch authenticator from config
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	// Instantiate the service with an API key based IAM authenticator
+	// Instantiate the service with the connection authenticator
 	service, err := globalloadbalancerv1.NewGlobalLoadBalancerV1(&globalloadbalancerv1.GlobalLoadBalancerV1Options{
-		Crn:            &serviceInstanceID,
+		Crn:            &instanceCRN,
 		ZoneIdentifier: &zoneId,
 		URL:            endpoint,
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		Authenticator:  authenticator,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointCIS, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 
 	return service, nil
 }
 
-// cisGlobalLoadBalancerService returns the service for IBM CIS DNS service
-func cisDnsRecordService(ctx context.Context, d *plugin.QueryData, zoneId string) (*dnsrecordsv1.DnsRecordsV1, error) {
-	serviceInstanceID := "crn:v1:bluemix:public:internet-svcs:global:a/76aa4877fab6436db86f121f62faf221:3e5dc1e0-3aea-4699-986e-e3b8f117c51d::"
-	endpoint := "https://api.cis.cloud.ibm.com"
+// globalSearchService returns the service for IBM Global Search service
+func globalSearchService(ctx context.Context, d *plugin.QueryData) (*globalsearchv2.GlobalSearchV2, error) {
+	// Load connection from cache, which preserves throttling protection etc
+	cacheKey := "ibm_global_search"
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*globalsearchv2.GlobalSearchV2), nil
+	}
+	authenticator, err := getAuthenticator(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	opts := &globalsearchv2.GlobalSearchV2Options{
+		URL:           serviceEndpoint(d, endpointGlobalSearch, ""),
+		Authenticator: authenticator,
+	}
+	service, err := globalsearchv2.NewGlobalSearchV2(opts)
+	if err != nil {
+		return nil, err
+	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointGlobalSearch, "")
+
+	// Save to cache
+	d.ConnectionManager.Cache.Set(cacheKey, service)
+	return service, nil
+}
+
+// cisDnsRecordService returns the service for IBM CIS DNS service
+func cisDnsRecordService(ctx context.Context, d *plugin.QueryData, instanceCRN string, zoneId string) (*dnsrecordsv1.DnsRecordsV1, error) {
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("instance CRN must be passed cisDnsRecordService")
+	}
+	endpoint := serviceEndpoint(d, endpointCIS, "")
 
 	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "cisDnsRecord"
+	cacheKey := "cisDnsRecord" + instanceCRN + zoneId
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*dnsrecordsv1.DnsRecordsV1), nil
 	}
 
-	// Fetch API key from config
-	apiKey, err := configApiKey(ctx, d)
+	// Fetch authenticator from config
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	// Instantiate the service with an API key based IAM authenticator
+	// Instantiate the service with the connection authenticator
 	service, err := dnsrecordsv1.NewDnsRecordsV1(&dnsrecordsv1.DnsRecordsV1Options{
-		Crn:            &serviceInstanceID,
+		Crn:            &instanceCRN,
 		ZoneIdentifier: &zoneId,
 		URL:            endpoint,
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		Authenticator:  authenticator,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointCIS, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 
@@ -238,18 +307,22 @@ func iamService(ctx context.Context, d *plugin.QueryData) (*iamidentityv1.IamIde
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*iamidentityv1.IamIdentityV1), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	serviceClientOptions := &iamidentityv1.IamIdentityV1Options{Authenticator: &core.IamAuthenticator{
-		ApiKey: apiKey,
-	}}
-	// Instantiate the service with an API key based IAM authenticator
+	serviceClientOptions := &iamidentityv1.IamIdentityV1Options{
+		URL:           serviceEndpoint(d, endpointIAM, ""),
+		Authenticator: authenticator,
+	}
+	// Instantiate the service with the connection authenticator
 	service, err := iamidentityv1.NewIamIdentityV1UsingExternalConfig(serviceClientOptions)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointIAM, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
@@ -261,17 +334,22 @@ func iamAccessGroupService(ctx context.Context, d *plugin.QueryData) (*iamaccess
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*iamaccessgroupsv2.IamAccessGroupsV2), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	serviceClientOptions := &iamaccessgroupsv2.IamAccessGroupsV2Options{Authenticator: &core.IamAuthenticator{
-		ApiKey: apiKey,
-	}}
+	// The access groups API is versioned in the path of the service URL
+	serviceClientOptions := &iamaccessgroupsv2.IamAccessGroupsV2Options{
+		URL:           serviceEndpoint(d, endpointIAM, "") + "/v2",
+		Authenticator: authenticator,
+	}
 	service, err := iamaccessgroupsv2.NewIamAccessGroupsV2UsingExternalConfig(serviceClientOptions)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointIAM, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
@@ -283,17 +361,21 @@ func iamPolicyManagementService(ctx context.Context, d *plugin.QueryData) (*iamp
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*iampolicymanagementv1.IamPolicyManagementV1), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	serviceClientOptions := &iampolicymanagementv1.IamPolicyManagementV1Options{Authenticator: &core.IamAuthenticator{
-		ApiKey: apiKey,
-	}}
+	serviceClientOptions := &iampolicymanagementv1.IamPolicyManagementV1Options{
+		URL:           serviceEndpoint(d, endpointIAM, ""),
+		Authenticator: authenticator,
+	}
 	service, err := iampolicymanagementv1.NewIamPolicyManagementV1UsingExternalConfig(serviceClientOptions)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointIAM, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
@@ -305,19 +387,21 @@ func tagService(ctx context.Context, d *plugin.QueryData) (*globaltaggingv1.Glob
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*globaltaggingv1.GlobalTaggingV1), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 	opts := &globaltaggingv1.GlobalTaggingV1Options{
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		URL:           serviceEndpoint(d, endpointGlobalTagging, ""),
+		Authenticator: authenticator,
 	}
 	service, err := globaltaggingv1.NewGlobalTaggingV1(opts)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointGlobalTagging, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
@@ -329,19 +413,21 @@ func resourceControllerService(ctx context.Context, d *plugin.QueryData) (*resou
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*resourcecontrollerv2.ResourceControllerV2), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 	opts := &resourcecontrollerv2.ResourceControllerV2Options{
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		URL:           serviceEndpoint(d, endpointResourceController, ""),
+		Authenticator: authenticator,
 	}
 	service, err := resourcecontrollerv2.NewResourceControllerV2(opts)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointResourceController, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
@@ -353,45 +439,54 @@ func resourceManagerService(ctx context.Context, d *plugin.QueryData) (*resource
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*resourcemanagerv2.ResourceManagerV2), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 	opts := &resourcemanagerv2.ResourceManagerV2Options{
-		Authenticator: &core.IamAuthenticator{
-			ApiKey: apiKey,
-		},
+		URL:           serviceEndpoint(d, endpointResourceManager, ""),
+		Authenticator: authenticator,
 	}
 	service, err := resourcemanagerv2.NewResourceManagerV2(opts)
 	if err != nil {
 		return nil, err
 	}
+	// Limit the request rate, and retry rate limited requests and server errors
+	enableRetries(ctx, d, service.Service, endpointResourceManager, "")
+
 	// Save to cache
 	d.ConnectionManager.Cache.Set(cacheKey, service)
 	return service, nil
 }
 
-func cosService(ctx context.Context, d *plugin.QueryData, region string) (*s3.S3, error) {
+func cosService(ctx context.Context, d *plugin.QueryData, instanceCRN, region string) (*s3.S3, error) {
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("instance CRN must be passed cosService")
+	}
 
-	serviceInstanceID := d.EqualsQualString("instance_crn")
-	// Load connection from cache, which preserves throttling protection etc
-	cacheKey := "ibm_cos" + region
+	// Load connection from cache, which preserves throttling protection etc.
+	// The credentials of the client are bound to the service instance.
+	cacheKey := "ibm_cos_" + region + "_" + instanceCRN
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*s3.S3), nil
 	}
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
-	authEndpoint := "https://iam.cloud.ibm.com/identity/token"
-	serviceEndpoint := fmt.Sprintf("s3.%s.cloud-object-storage.appdomain.cloud", region)
+	endpoint := serviceEndpoint(d, endpointCOS, region)
 
 	conf := aws.NewConfig().
-		WithEndpoint(serviceEndpoint).
-		WithCredentials(ibmiam.NewStaticCredentials(aws.NewConfig(),
-			authEndpoint, apiKey, serviceInstanceID)).
+		WithEndpoint(endpoint).
+		WithCredentials(credentials.NewCredentials(&authenticatorCredentials{
+			authenticator:     authenticator,
+			serviceInstanceID: instanceCRN,
+		})).
 		WithS3ForcePathStyle(true)
 
+	// Limit the request rate, and retry rate limited requests and server errors
+	conf = cosRetryConfig(ctx, d, region, conf)
+
 	sess := session.Must(session.NewSession())
 
 	service := s3.New(sess, conf)
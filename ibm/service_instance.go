@@ -2,6 +2,9 @@ package ibm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/IBM/go-sdk-core/v5/core"
@@ -9,6 +12,11 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// matrixErrorKey is the only key of the matrix item returned when a matrix
+// cannot be built, so the error is raised by the table's hydrate functions
+// instead of crashing the plugin
+const matrixErrorKey = "matrix_error"
+
 // BuildServiceInstanceList :: return a list of matrix items, one per service instance
 func BuildServiceInstanceList(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
 	// cache service instance region matrix
@@ -21,7 +29,10 @@ func BuildServiceInstanceList(ctx context.Context, d *plugin.QueryData) []map[st
 	// get all the service instances in the account
 	serviceInstances, err := listAllServiceInstances(ctx, d, d.Connection)
 	if err != nil {
-		panic(err)
+		plugin.Logger(ctx).Error("BuildServiceInstanceList", "connection", d.Connection.Name, "error", err)
+		return []map[string]interface{}{
+			{matrixErrorKey: fmt.Sprintf("connection %q failed to list service instances: %v", d.Connection.Name, err)},
+		}
 	}
 
 	matrix := make([]map[string]interface{}, len(serviceInstances))
@@ -57,21 +68,65 @@ func listAllServiceInstances(ctx context.Context, d *plugin.QueryData, connectio
 	var serviceInstanceCRNs []*string
 
 	opts := &resourcecontrollerv2.ListResourceInstancesOptions{
-		Type: core.StringPtr("service_instance"),
+		Type:  core.StringPtr("service_instance"),
+		Limit: core.Int64Ptr(100),
 	}
 
-	response, _, err := session.ListResourceInstances(opts)
-	if err != nil {
-		plugin.Logger(ctx).Error("listAllServiceInstances", "query_error", err)
-		return nil, err
-	}
+	for {
+		response, _, err := session.ListResourceInstances(opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("listAllServiceInstances", "query_error", err)
+			return nil, err
+		}
+
+		for _, i := range response.Resources {
+			serviceInstanceCRNs = append(serviceInstanceCRNs, i.CRN)
+		}
 
-	for _, i := range response.Resources {
-		serviceInstanceCRNs = append(serviceInstanceCRNs, i.CRN)
+		// The next page is requested with the start token of the next URL
+		start, err := core.GetQueryParam(response.NextURL, "start")
+		if err != nil {
+			plugin.Logger(ctx).Error("listAllServiceInstances", "next_url_error", err)
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		opts.Start = start
 	}
 
 	// save service instances in cache
 	d.ConnectionManager.Cache.Set(serviceCacheKey, serviceInstanceCRNs)
 
-	return serviceInstanceCRNs, err
+	return serviceInstanceCRNs, nil
+}
+
+// BuildServiceInstanceListByType returns a matrix function which only includes
+// the service instances of the given service types, so tables are not called
+// for instances of other services
+func BuildServiceInstanceListByType(serviceTypes ...string) plugin.MatrixItemMapFunc {
+	return func(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+		serviceInstances := BuildServiceInstanceList(ctx, d)
+
+		matrix := []map[string]interface{}{}
+		for _, item := range serviceInstances {
+			// Keep the error item, so the error is raised by the table
+			if _, ok := item[matrixErrorKey]; ok {
+				return serviceInstances
+			}
+			if slices.Contains(serviceTypes, item["service_type"].(string)) {
+				matrix = append(matrix, item)
+			}
+		}
+
+		return matrix
+	}
+}
+
+// getMatrixError returns the error recorded on the current matrix item, if any
+func getMatrixError(d *plugin.QueryData) error {
+	if message := d.EqualsQualString(matrixErrorKey); message != "" {
+		return errors.New(message)
+	}
+	return nil
 }
@@ -19,6 +19,7 @@ func tableIbmAccount(ctx context.Context) *plugin.Table {
 		Description: "IBM Cloud Account.",
 		List: &plugin.ListConfig{
 			Hydrate: listAccount,
+			Tags:    serviceTags(rateLimiterServiceAccount),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "name", Type: proto.ColumnType_STRING, Description: "Specifies the name of the account."},
@@ -73,7 +74,7 @@ type AccountInfo struct {
 //// LIST FUNCTION
 
 func listAccount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, rateLimiterServiceAccount, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_account.listAccount", "connection_error", err)
 		return nil, err
@@ -16,9 +16,10 @@ func tableIbmCertificateManagerCertificate(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:              "ibm_certificate_manager_certificate",
 		Description:       "Retrieve the details of an existing certificate instance resource and lists all the certificates.",
-		GetMatrixItemFunc: BuildServiceInstanceList,
+		GetMatrixItemFunc: BuildServiceInstanceListByType("cloudcerts"),
 		List: &plugin.ListConfig{
 			Hydrate: listCertificate,
+			Tags:    serviceTags(rateLimiterServiceCertificateManager),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "certificate_manager_instance_id",
@@ -32,7 +33,7 @@ func tableIbmCertificateManagerCertificate(ctx context.Context) *plugin.Table {
 			{Name: "description", Type: proto.ColumnType_STRING, Description: "The description of the certificate."},
 			{Name: "status", Type: proto.ColumnType_STRING, Description: "The status of a certificate."},
 			{Name: "serial_number", Type: proto.ColumnType_STRING, Description: "The serial number of a certificate."},
-			{Name: "certificate_manager_instance_id", Type: proto.ColumnType_STRING, Description: "The CRN of the certificate manager service instance.", Hydrate: plugin.HydrateFunc(getServiceInstanceCRN)},
+			{Name: "certificate_manager_instance_id", Type: proto.ColumnType_STRING, Description: "The CRN of the certificate manager service instance.", Hydrate: plugin.HydrateFunc(getServiceInstanceCRN), Transform: transform.FromValue()},
 			{Name: "algorithm", Type: proto.ColumnType_STRING, Description: "The Algorithm of a certificate."},
 			{Name: "auto_renew_enabled", Type: proto.ColumnType_BOOL, Description: "The automatic renewal status of the certificate.", Transform: transform.FromField("OrderPolicy.AutoRenewEnabled"), Default: false},
 			{Name: "begins_on", Type: proto.ColumnType_TIMESTAMP, Description: "The creation date of the certificate.", Transform: transform.FromField("BeginsOn").Transform(transform.UnixMsToTimestamp)},
@@ -46,7 +47,7 @@ func tableIbmCertificateManagerCertificate(ctx context.Context) *plugin.Table {
 			{Name: "key_algorithm", Type: proto.ColumnType_STRING, Description: "An alphanumeric value identifying the account ID."},
 			{Name: "order_policy_name", Type: proto.ColumnType_STRING, Description: "The order policy name of the certificate.", Transform: transform.FromField("OrderPolicy.Name")},
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Description: "The region of this certificate.", Hydrate: plugin.HydrateFunc(getRegion)},
+			{Name: "region", Type: proto.ColumnType_STRING, Description: "The region of this certificate.", Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue()},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("ID").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
 		}),
@@ -61,6 +62,11 @@ func listCertificate(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	instanceCRN := d.EqualsQualString("instance_crn")
 	serviceType := d.EqualsQualString("service_type")
 
+	// Raise any error from building the service instance matrix
+	if err := getMatrixError(d); err != nil {
+		return nil, err
+	}
+
 	// Invalid service type
 	if serviceType != "cloudcerts" {
 		return nil, nil
@@ -72,7 +78,7 @@ func listCertificate(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 
 	// Create service connection
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, rateLimiterServiceCertificateManager, d.EqualsQualString("region"))
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_certificate_manager_certificate.listCertificate", "connection_error", err)
 		return nil, err
@@ -0,0 +1,65 @@
+package ibm
+
+import (
+	"net/http"
+	"testing"
+)
+
+const testCertificateManagerInstanceCRN = "crn:v1:bluemix:public:cloudcerts:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:7a8b9c0d-1e2f-4a3b-9c4d-5e6f7a8b9c0d::"
+
+func TestCertificateManagerCertificate(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/service_instances.json")
+
+	// The second page starts from the document of the first page
+	cloud.CertificateManager.handle("GET /api/v3/{crn}/certificates", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("crn") != testCertificateManagerInstanceCRN {
+			t.Errorf("certificates listed for instance %s", r.PathValue("crn"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("start_from_document_id") != "" {
+			w.Write(readFixture(t, "certificate_manager/certificates_2.json"))
+			return
+		}
+		w.Write(readFixture(t, "certificate_manager/certificates.json"))
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_certificate_manager_certificate", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	certificates := rowsByColumn(t, rows, "name")
+
+	assertColumns(t, certificates["www.example.com"], map[string]interface{}{
+		"id":                              "crn:v1:bluemix:public:cloudcerts:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:7a8b9c0d-1e2f-4a3b-9c4d-5e6f7a8b9c0d:certificate:8e2f4a6b9c0d1e2f3a4b5c6d7e8f9a0b",
+		"certificate_manager_instance_id": testCertificateManagerInstanceCRN,
+		"status":                          "valid",
+		"serial_number":                   "03:4f:2a:9b:1c:7d:5e:8f",
+		"auto_renew_enabled":              true,
+		"begins_on":                       "2023-01-01T12:00:00Z",
+		"expires_on":                      "2023-04-01T12:00:00Z",
+		"domains":                         []string{"www.example.com", "example.com"},
+		"has_previous":                    true,
+		"imported":                        false,
+		"order_policy_name":               "default",
+		"region":                          "us-south",
+		"account_id":                      testAccountID,
+	})
+
+	// An imported certificate has no order policy
+	assertColumns(t, certificates["internal.example.com"], map[string]interface{}{
+		"imported":           true,
+		"auto_renew_enabled": false,
+		"order_policy_name":  "",
+	})
+
+	t.Run("other instance", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_certificate_manager_certificate", map[string]interface{}{
+			"certificate_manager_instance_id": "crn:v1:bluemix:public:cloudcerts:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:00000000-0000-0000-0000-000000000000::",
+		})
+		if len(rows) != 0 {
+			t.Errorf("got %d rows, want none", len(rows))
+		}
+	})
+}
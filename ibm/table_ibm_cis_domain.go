@@ -17,19 +17,44 @@ import (
 
 func tableIbmCISDomain(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
-		Name:        "ibm_cis_domain",
-		Description: "IBM CIS Domain",
+		Name:              "ibm_cis_domain",
+		Description:       "IBM CIS Domain",
+		GetMatrixItemFunc: BuildServiceInstanceListByType("internet-svcs"),
 		List: &plugin.ListConfig{
 			Hydrate: listCISDomains,
+			Tags:    serviceTags(endpointCIS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "cis_instance_crn",
+					Require: plugin.Optional,
+				},
+			},
 		},
 		Get: &plugin.GetConfig{
-			Hydrate:    getCISDomain,
-			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate: getCISDomain,
+			Tags:    serviceTags(endpointCIS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "cis_instance_crn",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getTlsMinimumVersion, Tags: serviceTags(endpointCIS)},
+			{Func: getWebApplicationFirewall, Tags: serviceTags(endpointCIS)},
+			{Func: getDnsRecords, Tags: serviceTags(endpointCIS)},
+			{Func: getGlobalLoadBalancer, Tags: serviceTags(endpointCIS)},
 		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The zone id."},
 			{Name: "name", Type: proto.ColumnType_STRING, Description: "The zone name."},
+			{Name: "cis_instance_crn", Type: proto.ColumnType_STRING, Description: "The CRN of the CIS instance that the zone belongs to.", Hydrate: plugin.HydrateFunc(getServiceInstanceCRN), Transform: transform.FromValue()},
 
 			// Other columns
 			{Name: "created_on", Type: proto.ColumnType_TIMESTAMP, Description: "The date and time that the zone was created."},
@@ -55,8 +80,26 @@ func tableIbmCISDomain(ctx context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listCISDomains(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
+	serviceType := d.EqualsQualString("service_type")
+
+	// Raise any error from building the service instance matrix
+	if err := getMatrixError(d); err != nil {
+		return nil, err
+	}
+
+	// Invalid service type
+	if serviceType != "internet-svcs" {
+		return nil, nil
+	}
+
+	// Return if specified instance CRN not matched
+	if d.EqualsQuals["cis_instance_crn"] != nil && d.EqualsQuals["cis_instance_crn"].GetStringValue() != instanceCRN {
+		return nil, nil
+	}
+
 	// Create service connection
-	conn, err := cisZoneService(ctx, d)
+	conn, err := cisZoneService(ctx, d, instanceCRN)
 	if err != nil {
 		plugin.Logger(ctx).Error("listCISDomains", "connection_error", err)
 		return nil, err
@@ -80,8 +123,26 @@ func listCISDomains(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 //// HYDRATE FUNCTIONS
 
 func getCISDomain(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
+	serviceType := d.EqualsQualString("service_type")
+
+	// Raise any error from building the service instance matrix
+	if err := getMatrixError(d); err != nil {
+		return nil, err
+	}
+
+	// Invalid service type
+	if serviceType != "internet-svcs" {
+		return nil, nil
+	}
+
+	// Return if specified instance CRN not matched
+	if d.EqualsQuals["cis_instance_crn"] != nil && d.EqualsQuals["cis_instance_crn"].GetStringValue() != instanceCRN {
+		return nil, nil
+	}
+
 	// Create service connection
-	conn, err := cisZoneService(ctx, d)
+	conn, err := cisZoneService(ctx, d, instanceCRN)
 	if err != nil {
 		plugin.Logger(ctx).Error("getCISDomain", "connection_error", err)
 		return nil, err
@@ -110,10 +171,11 @@ func getCISDomain(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 }
 
 func getTlsMinimumVersion(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
 	id := h.Item.(zonesv1.ZoneDetails).ID
 
 	// Create service connection
-	conn, err := cisZoneSettingService(ctx, d, *id)
+	conn, err := cisZoneSettingService(ctx, d, instanceCRN, *id)
 	if err != nil {
 		plugin.Logger(ctx).Error("getTlsMinimumVersion", "connection_error", err)
 		return nil, err
@@ -132,9 +194,10 @@ func getTlsMinimumVersion(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 }
 
 func getWebApplicationFirewall(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
 	id := h.Item.(zonesv1.ZoneDetails).ID
 	// Create service connection
-	conn, err := cisZoneSettingService(ctx, d, *id)
+	conn, err := cisZoneSettingService(ctx, d, instanceCRN, *id)
 	if err != nil {
 		plugin.Logger(ctx).Error("getWebApplicationFirewall", "connection_error", err)
 		return nil, err
@@ -152,9 +215,10 @@ func getWebApplicationFirewall(ctx context.Context, d *plugin.QueryData, h *plug
 }
 
 func getGlobalLoadBalancer(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
 	id := h.Item.(zonesv1.ZoneDetails).ID
 	// Create service connection
-	conn, err := cisGlobalLoadBalancerService(ctx, d, *id)
+	conn, err := cisGlobalLoadBalancerService(ctx, d, instanceCRN, *id)
 	if err != nil {
 		plugin.Logger(ctx).Error("cisGlobalLoadBalancerService", "connection_error", err)
 		return nil, err
@@ -172,9 +236,10 @@ func getGlobalLoadBalancer(ctx context.Context, d *plugin.QueryData, h *plugin.H
 }
 
 func getDnsRecords(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instanceCRN := d.EqualsQualString("instance_crn")
 	id := h.Item.(zonesv1.ZoneDetails).ID
 	// Create service connection
-	conn, err := cisDnsRecordService(ctx, d, *id)
+	conn, err := cisDnsRecordService(ctx, d, instanceCRN, *id)
 	if err != nil {
 		plugin.Logger(ctx).Error("getDnsRecords", "connection_error", err)
 		return nil, err
@@ -0,0 +1,85 @@
+package ibm
+
+import (
+	"testing"
+)
+
+const testCisInstanceCRN = "crn:v1:bluemix:public:internet-svcs:global:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:3e4f5a6b-7c8d-4e9f-8a0b-1c2d3e4f5a6b::"
+
+func newFakeCisCloud(t *testing.T) *fakeCloud {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/service_instances.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones", "cis/zones.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones/{zone}", "cis/zone.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones/{zone}/settings/min_tls_version", "cis/min_tls_version.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones/{zone}/settings/waf", "cis/waf.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones/{zone}/load_balancers", "cis/load_balancers.json")
+	cloud.CIS.fixture("GET /v1/{crn}/zones/{zone}/dns_records", "cis/dns_records.json")
+	return cloud
+}
+
+func TestCisDomain(t *testing.T) {
+	expected := map[string]interface{}{
+		"id":                       "0a1b2c3d4e5f60718293a4b5c6d7e8f9",
+		"name":                     "example.com",
+		"cis_instance_crn":         testCisInstanceCRN,
+		"account_id":               testAccountID,
+		"created_on":               "2023-02-14T09:12:45Z",
+		"status":                   "active",
+		"paused":                   false,
+		"minimum_tls_version":      "1.2",
+		"web_application_firewall": "on",
+		"name_servers":             []string{"ns001.name.cloud.ibm.com", "ns002.name.cloud.ibm.com"},
+		"title":                    "example.com",
+		"akas":                     []string{"0a1b2c3d4e5f60718293a4b5c6d7e8f9"},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cloud := newFakeCisCloud(t)
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_cis_domain", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+
+		records, _ := rows[0]["dns_records"].([]interface{})
+		if len(records) != 1 || records[0].(map[string]interface{})["content"] != "192.0.2.10" {
+			t.Errorf("dns_records = %v, want the A record of www.example.com", rows[0]["dns_records"])
+		}
+		balancers, _ := rows[0]["global_load_balancer"].([]interface{})
+		if len(balancers) != 1 || balancers[0].(map[string]interface{})["name"] != "www.example.com" {
+			t.Errorf("global_load_balancer = %v, want www.example.com", rows[0]["global_load_balancer"])
+		}
+
+		// The zones are listed from the CIS instance
+		if got := cloud.CIS.requestsTo("/v1/" + testCisInstanceCRN + "/zones"); len(got) != 1 {
+			t.Errorf("got %d zone list requests, want 1", len(got))
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		cloud := newFakeCisCloud(t)
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_cis_domain", map[string]interface{}{"id": "0a1b2c3d4e5f60718293a4b5c6d7e8f9"})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+	})
+
+	t.Run("other instance", func(t *testing.T) {
+		cloud := newFakeCisCloud(t)
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_cis_domain", map[string]interface{}{"cis_instance_crn": "crn:v1:bluemix:public:internet-svcs:global:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:00000000-0000-0000-0000-000000000000::"})
+		if len(rows) != 0 {
+			t.Errorf("got %d rows, want none", len(rows))
+		}
+		if got := cloud.CIS.requestsTo("/v1/" + testCisInstanceCRN + "/zones"); len(got) != 0 {
+			t.Errorf("got %d zone list requests, want none", len(got))
+		}
+	})
+}
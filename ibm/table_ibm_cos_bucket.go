@@ -4,7 +4,9 @@ import (
 	"context"
 	"strings"
 
+	"github.com/IBM/ibm-cos-sdk-go/aws"
 	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -16,13 +18,25 @@ func tableCosBucket(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:          "ibm_cos_bucket",
 		Description:   "An IBM Cloud storage bucket.",
-		GetMatrixItemFunc: BuildServiceInstanceList,
+		GetMatrixItemFunc: BuildServiceInstanceListByType("cloud-object-storage"),
 		List: &plugin.ListConfig{
 			Hydrate: listBucket,
+			Tags:    serviceTags(endpointCOS),
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: headBucket, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketVersioning, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketACL, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketLifecycle, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketPublicAccessBlockConfiguration, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketRetention, Tags: serviceTags(endpointCOS)},
+			{Func: getBucketWebsite, Tags: serviceTags(endpointCOS)},
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "name", Type: proto.ColumnType_STRING, Description: "Name of the bucket."},
 			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the bucket was created."},
+			{Name: "instance_crn", Type: proto.ColumnType_STRING, Description: "The CRN of the Cloud Object Storage instance of the bucket.", Hydrate: plugin.HydrateFunc(getServiceInstanceCRN), Transform: transform.FromValue()},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The GUID of the Cloud Object Storage instance of the bucket.", Hydrate: plugin.HydrateFunc(getServiceInstanceID), Transform: transform.FromValue()},
 			{Name: "sse_kp_customer_root_key_crn", Type: proto.ColumnType_STRING, Description: "The root key used by Key Protect to encrypt this bucket. This value must be the full CRN of the root key.", Hydrate: headBucket, Transform: transform.FromField("IBMSSEKPCrkId")},
 			{Name: "sse_kp_enabled", Type: proto.ColumnType_BOOL, Description: "Specifies whether the Bucket has Key Protect enabled.", Hydrate: headBucket, Transform: transform.FromField("IBMSSEKPEnabled"), Default: false},
 			{Name: "versioning_enabled", Type: proto.ColumnType_BOOL, Description: "The versioning state of a bucket.", Hydrate: getBucketVersioning, Transform: transform.FromField("Status").Transform(handleNilString).Transform(transform.ToBool)},
@@ -31,6 +45,7 @@ func tableCosBucket(ctx context.Context) *plugin.Table {
 			{Name: "lifecycle_rules", Type: proto.ColumnType_JSON, Description: "The lifecycle configuration information of the bucket.", Hydrate: getBucketLifecycle, Transform: transform.FromField("Rules")},
 			{Name: "public_access_block_configuration", Type: proto.ColumnType_JSON, Description: "The public access block configuration information of the bucket.", Hydrate: getBucketPublicAccessBlockConfiguration, Transform: transform.FromValue()},
 			{Name: "retention", Type: proto.ColumnType_JSON, Description: "The retention configuration information of the bucket.", Hydrate: getBucketRetention, Transform: transform.FromValue()},
+			{Name: "location", Type: proto.ColumnType_STRING, Description: "The location of the bucket, a region (e.g. us-south), a cross-region location (us, eu or ap) or a single data center (e.g. ams03).", Transform: transform.FromField("LocationConstraint").Transform(cosLocation)},
 			{Name: "website", Type: proto.ColumnType_JSON, Description: "The lifecycle configuration information of the bucket.", Hydrate: getBucketWebsite, Transform: transform.FromValue()},
 
 			// Standard columns
@@ -43,37 +58,59 @@ func tableCosBucket(ctx context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listBucket(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	region := GetDefaultIBMRegion(d)
+	region := GetDefaultIBMRegion(ctx, d)
 	plugin.Logger(ctx).Trace("listBucket")
 
+	instanceCRN := d.EqualsQualString("instance_crn")
 	serviceType := d.EqualsQualString("service_type")
 
+	// Raise any error from building the service instance matrix
+	if err := getMatrixError(d); err != nil {
+		return nil, err
+	}
+
 	// Invalid service type
 	if serviceType != "cloud-object-storage" {
 		return nil, nil
 	}
 
 	// Create service connection
-	conn, err := cosService(ctx, d, region)
+	conn, err := cosService(ctx, d, instanceCRN, region)
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_cos_bucket.listBucket", "connection_error", err)
 		return nil, err
 	}
 
-	opt := &s3.ListBucketsExtendedInput{}
+	// 1000 is the maximum number of buckets per page
+	maxResult := int64(1000)
 
-	data, err := conn.ListBucketsExtended(opt)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_cos_bucket.listBucket", "query_error", err)
-		return nil, err
+	// Reduce the basic request limit down if the user has only requested a small number of rows
+	limit := d.QueryContext.Limit
+	if d.QueryContext.Limit != nil {
+		if *limit < maxResult {
+			maxResult = *limit
+		}
 	}
-	for _, i := range data.Buckets {
-		d.StreamListItem(ctx, i)
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+	opt := &s3.ListBucketsExtendedInput{
+		MaxKeys: aws.Int64(maxResult),
+	}
+
+	// The next page starts after the marker, the last bucket of the page
+	err = conn.ListBucketsExtendedPagesWithContext(ctx, opt, func(page *s3.ListBucketsExtendedOutput, _ bool) bool {
+		for _, i := range page.Buckets {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
 		}
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_cos_bucket.listBucket", "query_error", err)
+		return nil, err
 	}
 	return nil, nil
 }
@@ -90,9 +127,8 @@ func headBucket(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -122,9 +158,8 @@ func getBucketLifecycle(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +189,8 @@ func getBucketRetention(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -187,10 +221,8 @@ func getBucketVersioning(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
-
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -222,10 +254,8 @@ func getBucketWebsite(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
-
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -256,10 +286,8 @@ func getBucketACL(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
-
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -286,10 +314,8 @@ func getBucketPublicAccessBlockConfiguration(ctx context.Context, d *plugin.Quer
 	}
 	bucket := h.Item.(*s3.BucketExtended)
 
-	location := strings.TrimSuffix(*bucket.LocationConstraint, "-smart")
-
 	// Create Session
-	conn, err := cosService(ctx, d, location)
+	conn, err := cosBucketService(ctx, d, bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -308,3 +334,14 @@ func getBucketPublicAccessBlockConfiguration(ctx context.Context, d *plugin.Quer
 
 	return result, nil
 }
+
+//// TRANSFORM FUNCTIONS
+
+// Transform the location constraint of a bucket to its location
+func cosLocation(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	locationConstraint := types.SafeString(d.Value)
+	if locationConstraint == "" {
+		return nil, nil
+	}
+	return cosBucketLocation(locationConstraint), nil
+}
@@ -0,0 +1,136 @@
+package ibm
+
+import (
+	"net/http"
+	"testing"
+)
+
+const testCosInstanceCRN = "crn:v1:bluemix:public:cloud-object-storage:global:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a::"
+
+// serveCosBucket serves the configuration of a bucket from the fixtures of
+// its subresources, e.g. ?versioning. A subresource without a fixture is not
+// configured, and fails with the error of the S3 API.
+func serveCosBucket(t *testing.T, bucket string, subresources map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("bucket") != bucket {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+
+		// HEAD returns the Key Protect configuration in headers
+		if r.Method == http.MethodHead {
+			if crn, ok := subresources["kp"]; ok {
+				w.Header().Set("ibm-sse-kp-enabled", "true")
+				w.Header().Set("ibm-sse-kp-customer-root-key-crn", crn)
+			}
+			return
+		}
+
+		for subresource, fixture := range subresources {
+			if r.URL.Query().Has(subresource) {
+				w.Write(readFixture(t, fixture))
+				return
+			}
+		}
+		notConfigured := map[string]string{
+			"lifecycle":         "cos/no_such_lifecycle.xml",
+			"website":           "cos/no_such_website.xml",
+			"publicAccessBlock": "cos/no_such_public_access_block.xml",
+		}
+		for subresource, fixture := range notConfigured {
+			if r.URL.Query().Has(subresource) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write(readFixture(t, fixture))
+				return
+			}
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestCosBucket(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/service_instances.json")
+
+	// The second page of buckets starts after the last bucket of the first
+	cloud.COS.handle("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Query().Get("marker") == "audit-logs" {
+			w.Write(readFixture(t, "cos/list_buckets_2.xml"))
+			return
+		}
+		w.Write(readFixture(t, "cos/list_buckets.xml"))
+	})
+	auditLogs := serveCosBucket(t, "audit-logs", map[string]string{
+		"kp":         "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:key:0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		"versioning": "cos/versioning.xml",
+		"acl":        "cos/acl.xml",
+		"lifecycle":  "cos/lifecycle.xml",
+		"protection": "cos/protection.xml",
+	})
+	staticSite := serveCosBucket(t, "static-site", map[string]string{
+		"versioning": "cos/versioning_never_enabled.xml",
+		"acl":        "cos/acl.xml",
+		"protection": "cos/protection.xml",
+		"website":    "cos/website.xml",
+	})
+	cloud.COS.handle("GET /{bucket}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("bucket") == "audit-logs" {
+			auditLogs(w, r)
+			return
+		}
+		staticSite(w, r)
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_cos_bucket", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	buckets := rowsByColumn(t, rows, "name")
+
+	assertColumns(t, buckets["audit-logs"], map[string]interface{}{
+		"account_id":                   testAccountID,
+		"creation_date":                "2023-01-10T12:00:00Z",
+		"instance_crn":                 testCosInstanceCRN,
+		"instance_id":                  "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a",
+		"location":                     "us-south",
+		"region":                       "us-south-smart",
+		"sse_kp_enabled":               true,
+		"sse_kp_customer_root_key_crn": "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:key:0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		"versioning_enabled":           true,
+		"versioning_mfa_delete":        false,
+		"lifecycle_rules": []map[string]interface{}{
+			{
+				"Expiration":  map[string]interface{}{"Date": nil, "Days": 90},
+				"Filter":      map[string]interface{}{"Prefix": ""},
+				"ID":          "expire-after-90-days",
+				"Status":      "Enabled",
+				"Transitions": nil,
+			},
+		},
+		"public_access_block_configuration": nil,
+		"website":                           nil,
+		"title":                             "audit-logs",
+	})
+
+	// A bucket without Key Protect, versioning or lifecycle rules
+	assertColumns(t, buckets["static-site"], map[string]interface{}{
+		"creation_date":                "2023-04-02T08:30:00Z",
+		"instance_crn":                 testCosInstanceCRN,
+		"location":                     "eu-de",
+		"sse_kp_enabled":               false,
+		"sse_kp_customer_root_key_crn": nil,
+		"versioning_enabled":           false,
+		"lifecycle_rules":              nil,
+		"website": map[string]interface{}{
+			"ErrorDocument":         map[string]interface{}{"Key": "error.html"},
+			"IndexDocument":         map[string]interface{}{"Suffix": "index.html"},
+			"RedirectAllRequestsTo": nil,
+			"RoutingRules":          nil,
+		},
+	})
+
+}
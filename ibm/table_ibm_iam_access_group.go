@@ -19,9 +19,11 @@ func tableIbmIamAccessGroup(ctx context.Context) *plugin.Table {
 		Description: "Access Groups in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate: listAccessGroup,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getAccessGroup,
+			Tags:       serviceTags(endpointIAM),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		Columns: commonColumns([]*plugin.Column{
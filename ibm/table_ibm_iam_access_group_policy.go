@@ -20,7 +20,9 @@ func tableIbmIamAccessGroupPolicy(ctx context.Context) *plugin.Table {
 		Description: "Group access policies in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate:       listGroupAccessPolicy,
+			Tags:          serviceTags(endpointIAM),
 			ParentHydrate: listAccessGroup,
+			ParentTags:    serviceTags(endpointIAM),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The ID of the IAM user policy."},
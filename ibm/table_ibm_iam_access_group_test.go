@@ -0,0 +1,82 @@
+package ibm
+
+import (
+	"testing"
+)
+
+const testAccessGroupID = "AccessGroupId-1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"
+
+func TestIamAccessGroup(t *testing.T) {
+	expected := map[string]interface{}{
+		"id":               testAccessGroupID,
+		"name":             "platform-admins",
+		"description":      "Administrators of the platform",
+		"is_federated":     false,
+		"created_at":       "2023-03-01T10:00:00Z",
+		"created_by_id":    "IBMid-test",
+		"last_modified_at": "2023-03-02T11:30:00Z",
+		"account_id":       testAccountID,
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v2/groups", "iam/access_groups.json")
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_access_group", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+
+		requests := cloud.IAM.requestsTo("/v2/groups")
+		if len(requests) != 1 || requests[0].URL.Query().Get("account_id") != testAccountID {
+			t.Errorf("access groups were not listed for the account of the token")
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v2/groups/{id}", "iam/access_group.json")
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_access_group", map[string]interface{}{"id": testAccessGroupID})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+	})
+}
+
+func TestIamAccessGroupPolicy(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.IAM.fixture("GET /v2/groups", "iam/access_groups.json")
+	cloud.IAM.fixture("GET /v1/policies", "iam/access_group_policies.json")
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_iam_access_group_policy", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"id":            "6a7b8c9d-0e1f-4a2b-9c3d-4e5f6a7b8c9d",
+		"group_id":      testAccessGroupID,
+		"type":          "access",
+		"created_at":    "2023-03-01T10:05:00Z",
+		"created_by_id": "IBMid-test",
+		"account_id":    testAccountID,
+		"roles": []map[string]interface{}{
+			{
+				"description":  "As an administrator, you can perform all platform actions based on the resource this role is being assigned, including assigning access policies to other users.",
+				"display_name": "Administrator",
+				"role_id":      "crn:v1:bluemix:public:iam::::role:Administrator",
+			},
+		},
+	})
+
+	// The policies are listed for every access group
+	requests := cloud.IAM.requestsTo("/v1/policies")
+	if len(requests) != 1 || requests[0].URL.Query().Get("access_group_id") != testAccessGroupID {
+		t.Errorf("policies were not listed for the access group")
+	}
+}
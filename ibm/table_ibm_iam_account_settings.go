@@ -19,6 +19,7 @@ func tableIbmAccountSettings(ctx context.Context) *plugin.Table {
 		Description: "Account setting information of IBM Cloud.",
 		List: &plugin.ListConfig{
 			Hydrate: getAccountSettings,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "restrict_create_service_id", Type: proto.ColumnType_STRING, Description: ""},
@@ -0,0 +1,30 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestIamAccountSettings(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.IAM.fixture("GET /v1/accounts/{account_id}/settings/identity", "iam/account_settings.json")
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_iam_account_settings", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"restrict_create_service_id":       "RESTRICTED",
+		"restrict_create_platform_api_key": "NOT_SET",
+		"allowed_ip_addresses":             "192.0.2.0/24",
+		"entity_tag":                       "3",
+		"mfa":                              "TOTP",
+		"session_expiration_in_seconds":    "86400",
+		"session_invalidation_in_seconds":  "7200",
+		"account_id":                       testAccountID,
+	})
+
+	if got := cloud.IAM.requestsTo("/v1/accounts/" + testAccountID + "/settings/identity"); len(got) != 1 {
+		t.Errorf("got %d account settings requests, want 1", len(got))
+	}
+}
@@ -34,6 +34,7 @@ func tableIbmIamAPIKey(ctx context.Context) *plugin.Table {
 		Description: "API keys in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate: listAPIKey,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Columns: commonColumns(iamAPIKeyColumns()),
 	}
@@ -0,0 +1,57 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestIamAPIKey(t *testing.T) {
+	expected := map[string]interface{}{
+		"id":          "ApiKey-9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+		"name":        "steampipe",
+		"crn":         "crn:v1:bluemix:public:iam-identity::a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::apikey:ApiKey-9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a",
+		"iam_id":      "IBMid-test",
+		"created_at":  "2023-01-05T08:00:00Z",
+		"modified_at": "2023-01-06T09:15:00Z",
+		"account_id":  testAccountID,
+	}
+
+	t.Run("account", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v1/apikeys", "iam/api_keys.json")
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_api_key", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+
+		// Every user API key of the account is listed
+		requests := cloud.IAM.requestsTo("/v1/apikeys")
+		if len(requests) != 1 {
+			t.Fatalf("got %d API key requests, want 1", len(requests))
+		}
+		query := requests[0].URL.Query()
+		if query.Get("account_id") != testAccountID || query.Get("scope") != "account" || query.Get("type") != "user" {
+			t.Errorf("API keys were listed with query %q", requests[0].URL.RawQuery)
+		}
+	})
+
+	t.Run("my", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v1/apikeys", "iam/api_keys.json")
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_my_api_key", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+
+		// Only the API keys of the caller are listed
+		requests := cloud.IAM.requestsTo("/v1/apikeys")
+		if len(requests) != 1 || requests[0].URL.RawQuery != "" {
+			t.Errorf("API keys of the caller were listed with a query")
+		}
+	})
+}
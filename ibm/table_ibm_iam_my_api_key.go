@@ -16,6 +16,7 @@ func tableIbmIamMyAPIKey(ctx context.Context) *plugin.Table {
 		Description: "User's API key in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate: listMyAPIKey,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Columns: commonColumns(iamAPIKeyColumns()),
 	}
@@ -3,8 +3,9 @@ package ibm
 import (
 	"context"
 
+	"github.com/IBM-Cloud/bluemix-go"
 	"github.com/IBM-Cloud/bluemix-go/api/iampap/iampapv2"
-
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
@@ -15,9 +16,11 @@ func tableIbmIamRole(ctx context.Context) *plugin.Table {
 		Description: "An IAM role is an Identity and Access Management (IAM) entity with permissions to make IBM cloud service requests.",
 		List: &plugin.ListConfig{
 			Hydrate: listIamRole,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIamRole,
+			Tags:       serviceTags(endpointIAM),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
 		Columns: commonColumns([]*plugin.Column{
@@ -37,13 +40,14 @@ func tableIbmIamRole(ctx context.Context) *plugin.Table {
 }
 
 func listIamRole(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, endpointIAM, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_role.listIamRole", "connection_error", err)
 		return nil, err
 	}
 
-	svc, err := iampapv2.New(conn)
+	// The client reads the IAM endpoint of the session, not the token endpoint
+	svc, err := iampapv2.New(conn.Copy(&bluemix.Config{Endpoint: core.StringPtr(serviceEndpoint(d, endpointIAM, ""))}))
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_role.listIamRole", "connection_error", err)
 		return nil, err
@@ -71,13 +75,14 @@ func listIamRole(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 }
 
 func getIamRole(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, endpointIAM, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_role.getIamRole", "connection_error", err)
 		return nil, err
 	}
 
-	svc, err := iampapv2.New(conn)
+	// The client reads the IAM endpoint of the session, not the token endpoint
+	svc, err := iampapv2.New(conn.Copy(&bluemix.Config{Endpoint: core.StringPtr(serviceEndpoint(d, endpointIAM, ""))}))
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_role.getIamRole", "connection_error", err)
 		return nil, err
@@ -0,0 +1,53 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestIamRole(t *testing.T) {
+	customRole := map[string]interface{}{
+		"id":               "7c4d1f1e-3b2a-4c5d-8e9f-0a1b2c3d4e5f",
+		"name":             "KeyReader",
+		"display_name":     "Key Reader",
+		"service_name":     "kms",
+		"actions":          []string{"kms.secrets.read"},
+		"created_at":       "2023-02-01T12:00:00Z",
+		"last_modified_at": "2023-02-02T12:00:00Z",
+		"account_id":       testAccountID,
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v2/roles", "iam/roles.json")
+		conn := newTestConnection(t, cloud.config())
+
+		// Custom, service and system roles are all listed
+		rows := conn.mustQuery("ibm_iam_role", nil)
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, want 2", len(rows))
+		}
+		roles := rowsByColumn(t, rows, "name")
+		assertColumns(t, roles["KeyReader"], customRole)
+		assertColumns(t, roles["Viewer"], map[string]interface{}{
+			"id":         "Viewer",
+			"created_at": nil,
+		})
+
+		requests := cloud.IAM.requestsTo("/v2/roles")
+		if len(requests) != 1 || requests[0].URL.Query().Get("account_id") != testAccountID {
+			t.Errorf("roles were not listed for the account of the token")
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		cloud := newFakeCloud(t)
+		cloud.IAM.fixture("GET /v2/roles/{id}", "iam/role.json")
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_role", map[string]interface{}{"id": "7c4d1f1e-3b2a-4c5d-8e9f-0a1b2c3d4e5f"})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], customRole)
+	})
+}
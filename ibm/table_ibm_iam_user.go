@@ -16,11 +16,16 @@ func tableIbmIamUser(ctx context.Context) *plugin.Table {
 		Description: "Users in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate: listIamUser,
+			Tags:    serviceTags(endpointIAM),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIamUser,
+			Tags:       serviceTags(endpointIAM),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getIamUserSettings, Tags: serviceTags(endpointIAM)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "An alphanumeric value identifying the user profile."},
 			{Name: "iam_id", Type: proto.ColumnType_STRING, Description: "An alphanumeric value identifying the user's IAM ID."},
@@ -39,7 +44,7 @@ func tableIbmIamUser(ctx context.Context) *plugin.Table {
 }
 
 func listIamUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, endpointIAM, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_user.listIamUser", "connection_error", err)
 		return nil, err
@@ -72,7 +77,7 @@ func listIamUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 
 func getIamUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, endpointIAM, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_user.getIamUser", "connection_error", err)
 		return nil, err
@@ -112,7 +117,7 @@ func getIamUser(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 func getIamUserSettings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	user := h.Item.(usermanagementv2.UserInfo)
 
-	conn, err := connect(ctx, d)
+	conn, err := connect(ctx, d, endpointIAM, "")
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_user.getIamUserSettings", "connection_error", err)
 		return nil, err
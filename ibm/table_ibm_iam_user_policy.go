@@ -20,7 +20,9 @@ func tableIbmIamUserPolicy(ctx context.Context) *plugin.Table {
 		Description: "User access policies in the IBM Cloud account.",
 		List: &plugin.ListConfig{
 			Hydrate:       listUserPolicy,
+			Tags:          serviceTags(endpointIAM),
 			ParentHydrate: listIamUser,
+			ParentTags:    serviceTags(endpointIAM),
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The ID of the IAM user policy."},
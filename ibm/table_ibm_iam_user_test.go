@@ -0,0 +1,103 @@
+package ibm
+
+import (
+	"net/http"
+	"testing"
+)
+
+// newFakeUserCloud serves the users of the account over two pages
+func newFakeUserCloud(t *testing.T) *fakeCloud {
+	cloud := newFakeCloud(t)
+	cloud.UserManagement.handle("GET /v2/accounts/{account_id}/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("_start") == "2" {
+			w.Write(readFixture(t, "user_management/users_2.json"))
+			return
+		}
+		w.Write(readFixture(t, "user_management/users.json"))
+	})
+	cloud.UserManagement.fixture("GET /v2/accounts/{account_id}/users/{iam_id}", "user_management/user.json")
+	cloud.UserManagement.fixture("GET /v2/accounts/{account_id}/users/{iam_id}/settings", "user_management/settings.json")
+	return cloud
+}
+
+func TestIamUser(t *testing.T) {
+	janeDoe := map[string]interface{}{
+		"id":          "5500abcdef-1a2b3c4d",
+		"iam_id":      "IBMid-5500abcdef",
+		"user_id":     "jane.doe@example.com",
+		"first_name":  "Jane",
+		"last_name":   "Doe",
+		"state":       "ACTIVE",
+		"phonenumber": "555-0100",
+		"account_id":  testAccountID,
+		"settings": map[string]interface{}{
+			"language":              "en",
+			"notification_language": "en",
+			"allowed_ip_addresses":  "192.0.2.10",
+			"self_manage":           true,
+		},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		cloud := newFakeUserCloud(t)
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_user", nil)
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, want 2", len(rows))
+		}
+		users := rowsByColumn(t, rows, "iam_id")
+		assertColumns(t, users["IBMid-5500abcdef"], janeDoe)
+		assertColumns(t, users["IBMid-5500fedcba"], map[string]interface{}{
+			"first_name": "John",
+			"state":      "PENDING",
+		})
+
+		// The settings are read for every user
+		if got := cloud.UserManagement.requestsTo("/v2/accounts/" + testAccountID + "/users/IBMid-5500fedcba/settings"); len(got) != 1 {
+			t.Errorf("got %d settings requests, want 1", len(got))
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		cloud := newFakeUserCloud(t)
+		conn := newTestConnection(t, cloud.config())
+
+		rows := conn.mustQuery("ibm_iam_user", map[string]interface{}{"id": "IBMid-5500abcdef"})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], janeDoe)
+	})
+}
+
+func TestIamUserPolicy(t *testing.T) {
+	cloud := newFakeUserCloud(t)
+	cloud.IAM.handle("GET /v1/policies", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("iam_id") != "IBMid-5500abcdef" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"policies": []interface{}{}})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "iam/user_policies.json"))
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_iam_user_policy", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"id":         "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e",
+		"iam_id":     "IBMid-5500abcdef",
+		"type":       "access",
+		"created_at": "2023-03-01T10:05:00Z",
+		"account_id": testAccountID,
+	})
+
+	// The policies are listed for every user
+	if got := cloud.IAM.requestsTo("/v1/policies"); len(got) != 2 {
+		t.Errorf("got %d policy requests, want 2", len(got))
+	}
+}
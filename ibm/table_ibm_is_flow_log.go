@@ -19,6 +19,7 @@ func tableIbmIsFlowLog(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate: listIsFlowLogs,
+			Tags:    serviceTags(endpointVPC),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "name",
@@ -28,8 +29,12 @@ func tableIbmIsFlowLog(ctx context.Context) *plugin.Table {
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsFlowLog,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 
 			// Top columns
@@ -47,12 +52,14 @@ func tableIbmIsFlowLog(ctx context.Context) *plugin.Table {
 			{Name: "storage_bucket", Type: proto.ColumnType_JSON, Description: "The Cloud Object Storage bucket where the collected flows are logged."},
 			{Name: "target", Type: proto.ColumnType_JSON, Description: "The target this collector is collecting flow logs for."},
 			{Name: "vpc", Type: proto.ColumnType_JSON, Description: "The VPC this flow log collector is associated with.", Transform: transform.FromField("VPC")},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this flow log collector."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this flow log collector."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getFlowLogTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -148,39 +155,3 @@ func getIsFlowLog(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 	}
 	return *result, nil
 }
-
-func getFlowLogTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	vpc := h.Item.(vpcv1.FlowLogCollector)
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_is_flow_log.getFlowLogTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*vpc.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_is_flow_log.getFlowLogTags", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
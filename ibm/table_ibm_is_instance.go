@@ -19,6 +19,7 @@ func tableIbmIsInstance(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate: listIsInstance,
+			Tags:    serviceTags(endpointVPC),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "name",
@@ -28,8 +29,13 @@ func tableIbmIsInstance(ctx context.Context) *plugin.Table {
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsInstance,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getInstanceNetworkInterfaceFloatingIps, Tags: serviceTags(endpointVPC)},
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The unique identifier for this virtual server instance."},
@@ -54,12 +60,14 @@ func tableIbmIsInstance(ctx context.Context) *plugin.Table {
 			{Name: "volume_attachments", Type: proto.ColumnType_JSON, Description: "A collection of the virtual server instance's volume attachments, including the boot volume attachment."},
 			{Name: "vpc", Type: proto.ColumnType_JSON, Description: "The VPC this virtual server instance resides in.", Transform: transform.FromField("VPC")},
 			{Name: "zone", Type: proto.ColumnType_JSON, Description: "The zone this virtual server instance resides in."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this instance."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this instance."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getInstanceTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -157,42 +165,6 @@ func getIsInstance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 	return *result, nil
 }
 
-func getInstanceTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	vpc := h.Item.(vpcv1.Instance)
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_is_instance.getInstanceTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*vpc.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_is_instance.getInstanceTags", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
-
 func getInstanceNetworkInterfaceFloatingIps(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	region := d.EqualsQualString("region")
 	vpc := h.Item.(vpcv1.Instance)
@@ -20,10 +20,13 @@ func tableIbmIsInstanceDisk(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate:       listIsInstanceDisk,
+			Tags:          serviceTags(endpointVPC),
 			ParentHydrate: listIsInstance,
+			ParentTags:    serviceTags(endpointVPC),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsInstanceDisk,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.AllColumns([]string{"id", "instance_id"}),
 		},
 		Columns: commonColumns([]*plugin.Column{
@@ -38,7 +41,7 @@ func tableIbmIsInstanceDisk(ctx context.Context) *plugin.Table {
 			{Name: "resource_type", Type: proto.ColumnType_STRING, Description: "The resource type."},
 			{Name: "size", Type: proto.ColumnType_INT, Description: "The size of the disk in GB (gigabytes)."},
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this instance disk."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this instance disk."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 		}),
 	}
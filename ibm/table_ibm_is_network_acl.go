@@ -19,11 +19,16 @@ func tableIbmIsNetworkAcl(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate: listIsNetworkAcl,
+			Tags:    serviceTags(endpointVPC),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsNetworkAcl,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The unique identifier for this network ACL"},
@@ -36,11 +41,13 @@ func tableIbmIsNetworkAcl(ctx context.Context) *plugin.Table {
 			{Name: "rules", Type: proto.ColumnType_JSON, Description: "The ordered rules for this network ACL. If no rules exist, all traffic will be denied."},
 			{Name: "subnets", Type: proto.ColumnType_JSON, Description: "The subnets to which this network ACL is attached."},
 			{Name: "vpc", Type: proto.ColumnType_JSON, Transform: transform.FromField("VPC"), Description: "he VPC this network ACL is a part of."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this subnet."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this subnet."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getNetworkAclTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -132,41 +139,3 @@ func getIsNetworkAcl(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 	return *result, nil
 }
-
-func getNetworkAclTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	item := h.Item.(vpcv1.NetworkACL)
-
-	// Create service connection
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_is_network_acl.getNetworkAclTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*item.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_is_network_acl.getNetworkAclTags", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
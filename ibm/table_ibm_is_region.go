@@ -18,9 +18,11 @@ func tableIbmIsRegion(ctx context.Context) *plugin.Table {
 		Description: "IBM Cloud regions and endpoints.",
 		List: &plugin.ListConfig{
 			Hydrate: listIsRegion,
+			Tags:    serviceTags(endpointVPC),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsRegion,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("name"),
 		},
 		Columns: commonColumns([]*plugin.Column{
@@ -42,7 +44,7 @@ func tableIbmIsRegion(ctx context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listIsRegion(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	region := GetDefaultIBMRegion(d)
+	region := GetDefaultIBMRegion(ctx, d)
 
 	// Create service connection
 	conn, err := vpcService(ctx, d, region)
@@ -74,7 +76,7 @@ func listIsRegion(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 //// HYDRATE FUNCTIONS
 
 func getIsRegion(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	region := GetDefaultIBMRegion(d)
+	region := GetDefaultIBMRegion(ctx, d)
 
 	// Create service connection
 	conn, err := vpcService(ctx, d, region)
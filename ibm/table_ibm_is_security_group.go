@@ -19,11 +19,16 @@ func tableIbmIsSecurityGroup(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate: listIsSecurityGroup,
+			Tags:    serviceTags(endpointVPC),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsSecurityGroup,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The unique identifier for this security group."},
@@ -37,11 +42,13 @@ func tableIbmIsSecurityGroup(ctx context.Context) *plugin.Table {
 			{Name: "rules", Type: proto.ColumnType_JSON, Description: "Array of rules for this security group. If no rules exist, all traffic will be denied."},
 			{Name: "targets", Type: proto.ColumnType_JSON, Description: "Array of references to targets."},
 			{Name: "vpc", Type: proto.ColumnType_JSON, Transform: transform.FromField("VPC"), Description: "The VPC this security group is a part of."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this security group."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this security group."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getSecurityGroupTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -133,39 +140,3 @@ func getIsSecurityGroup(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 	}
 	return *result, nil
 }
-
-func getSecurityGroupTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	item := h.Item.(vpcv1.SecurityGroup)
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_is_security_group.getSecurityGroupTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*item.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_is_security_group.getIsSecurityGroup", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
@@ -19,11 +19,16 @@ func tableIbmIsSubnet(ctx context.Context) *plugin.Table {
 		GetMatrixItemFunc: BuildRegionList,
 		List: &plugin.ListConfig{
 			Hydrate: listIsSubnet,
+			Tags:    serviceTags(endpointVPC),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getIsSubnet,
+			Tags:       serviceTags(endpointVPC),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "The unique identifier for this subnet."},
@@ -43,11 +48,13 @@ func tableIbmIsSubnet(ctx context.Context) *plugin.Table {
 			{Name: "total_ipv4_address_count", Type: proto.ColumnType_INT, Description: "The total number of IPv4 addresses in this subnet."},
 			{Name: "vpc", Type: proto.ColumnType_JSON, Transform: transform.FromField("VPC"), Description: "The VPC this subnet is a part of."},
 			{Name: "zone", Type: proto.ColumnType_JSON, Description: "The zone this subnet resides in."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this subnet."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this subnet."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getSubnetTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -139,39 +146,3 @@ func getIsSubnet(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 	}
 	return *result, nil
 }
-
-func getSubnetTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	item := h.Item.(vpcv1.Subnet)
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_is_subnet.getSubnetTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*item.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_is_subnet.getIsSubnet", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
@@ -0,0 +1,69 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestIsVpc(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.VPC.fixture("GET /v1/vpcs", "vpc/vpcs.json")
+	cloud.VPC.fixture("GET /v1/vpcs/{id}", "vpc/vpc.json")
+	cloud.VPC.fixture("GET /v1/vpcs/{id}/address_prefixes", "vpc/address_prefixes.json")
+	conn := newTestConnection(t, cloud.config())
+
+	expected := map[string]interface{}{
+		"account_id":     testAccountID,
+		"id":             "r006-4727d842-f94f-4a2d-824a-9bc9b02c523b",
+		"name":           "my-vpc",
+		"classic_access": false,
+		"created_at":     "2023-03-14T09:26:53Z",
+		"crn":            "crn:v1:bluemix:public:is:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::vpc:r006-4727d842-f94f-4a2d-824a-9bc9b02c523b",
+		"status":         "available",
+		"region":         "us-south",
+		"title":          "my-vpc",
+		"akas":           []string{"crn:v1:bluemix:public:is:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::vpc:r006-4727d842-f94f-4a2d-824a-9bc9b02c523b"},
+		"tags":           map[string][]string{"env": {"dev"}, "team": {"network"}, "shared": {""}},
+		"access_tags":    []string{"project:network"},
+		"service_tags":   []string{},
+		"address_prefixes": []map[string]interface{}{
+			{
+				"cidr":        "10.240.0.0/18",
+				"created_at":  "2023-03-14T09:26:54.000Z",
+				"has_subnets": true,
+				"href":        "https://us-south.iaas.cloud.ibm.com/v1/vpcs/r006-4727d842-f94f-4a2d-824a-9bc9b02c523b/address_prefixes/r006-1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+				"id":          "r006-1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d",
+				"is_default":  true,
+				"name":        "my-address-prefix-1",
+				"zone": map[string]string{
+					"href": "https://us-south.iaas.cloud.ibm.com/v1/regions/us-south/zones/us-south-1",
+					"name": "us-south-1",
+				},
+			},
+		},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_is_vpc", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_is_vpc", map[string]interface{}{"id": "r006-4727d842-f94f-4a2d-824a-9bc9b02c523b"})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+	})
+
+	t.Run("list with classic_access", func(t *testing.T) {
+		conn.mustQuery("ibm_is_vpc", map[string]interface{}{"classic_access": true}, "id")
+
+		requests := cloud.VPC.requestsTo("/v1/vpcs")
+		if got := requests[len(requests)-1].URL.Query().Get("classic_access"); got != "true" {
+			t.Errorf("classic_access = %q, want true", got)
+		}
+	})
+}
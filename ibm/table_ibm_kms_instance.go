@@ -0,0 +1,248 @@
+package ibm
+
+import (
+	"context"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmKmsInstance(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "ibm_kms_instance",
+		Description:       "A Key Protect or Hyper Protect Crypto Services instance holds keys and key rings, along with the policies which apply to all of them.",
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
+		List: &plugin.ListConfig{
+			Hydrate: listKmsInstances,
+			Tags:    serviceTags(endpointKMS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "instance_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getKmsInstanceKeyCount, Tags: serviceTags(endpointKMS)},
+			{Func: getKmsInstanceKeyRings, Tags: serviceTags(endpointKMS)},
+			{Func: getKmsInstanceAllowedNetwork, Tags: serviceTags(endpointKMS)},
+			{Func: getKmsInstanceImportToken, Tags: serviceTags(endpointKMS)},
+			{Func: getKmsInstanceKmipAdapters, Tags: serviceTags(endpointKMS)},
+			{Func: getKmsInstanceRegistrationCount, Tags: serviceTags(endpointKMS)},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Transform: transform.FromField("InstanceID")},
+			{Name: "instance_crn", Type: proto.ColumnType_STRING, Description: "The CRN of the key protect instance.", Transform: transform.FromField("InstanceCRN")},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Transform: transform.FromField("ServiceType")},
+			{Name: "key_count", Type: proto.ColumnType_INT, Description: "The number of keys in the instance, excluding deleted keys.", Hydrate: getKmsInstanceKeyCount, Transform: transform.FromValue()},
+			{Name: "key_ring_count", Type: proto.ColumnType_INT, Description: "The number of key rings in the instance.", Hydrate: getKmsInstanceKeyRings, Transform: transform.FromValue().Transform(kmsKeyRingCount)},
+			{Name: "allowed_network", Type: proto.ColumnType_STRING, Description: "The networks allowed to access the instance, public-and-private or private-only.", Hydrate: getKmsInstanceAllowedNetwork, Transform: transform.FromValue()},
+			{Name: "import_token_state", Type: proto.ColumnType_STRING, Description: "The state of the import token of the instance. Possible values are none, active, expired and exhausted.", Hydrate: getKmsInstanceImportToken, Transform: transform.FromValue().Transform(kmsImportTokenState), Default: "none"},
+			{Name: "registration_count", Type: proto.ColumnType_INT, Description: "The number of cloud resources registered with the keys of the instance.", Hydrate: getKmsInstanceRegistrationCount, Transform: transform.FromValue()},
+			{Name: "import_token", Type: proto.ColumnType_JSON, Description: "The metadata of the import token of the instance, used to import key material securely.", Hydrate: getKmsInstanceImportToken, Transform: transform.FromValue()},
+			{Name: "key_rings", Type: proto.ColumnType_JSON, Description: "The key rings of the instance.", Hydrate: getKmsInstanceKeyRings, Transform: transform.FromValue()},
+			{Name: "kmip_adapters", Type: proto.ColumnType_JSON, Description: "The KMIP adapters of the instance, which let KMIP clients such as VMware vSphere use its keys.", Hydrate: getKmsInstanceKmipAdapters, Transform: transform.FromValue()},
+
+			// Standard columns
+			{Name: "region", Type: proto.ColumnType_STRING, Description: "The region of this instance."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("InstanceID"), Description: resourceInterfaceDescription("title")},
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("InstanceCRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
+		}),
+	}
+}
+
+type kmsInstanceInfo struct {
+	InstanceID  string
+	InstanceCRN string
+	Region      string
+	ServiceType string
+}
+
+//// LIST FUNCTION
+
+func listKmsInstances(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if ok, err := isKmsMatrixInstance(d); !ok {
+		return nil, err
+	}
+
+	// Every matrix item is an instance
+	d.StreamListItem(ctx, kmsInstanceInfo{
+		InstanceID:  d.EqualsQualString("instance_id"),
+		InstanceCRN: d.EqualsQualString("instance_crn"),
+		Region:      d.EqualsQualString("region"),
+		ServiceType: d.EqualsQualString("service_type"),
+	})
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getKmsInstanceKeyCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKeyCount", "connection_error", err)
+		return nil, err
+	}
+
+	count, err := getKmsTotalCount(ctx, conn, "keys")
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKeyCount", "query_error", err)
+		return nil, err
+	}
+
+	return count, nil
+}
+
+func getKmsInstanceKeyRings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKeyRings", "connection_error", err)
+		return nil, err
+	}
+
+	data, err := conn.GetKeyRings(ctx)
+	if err != nil {
+		// Key rings are not supported by every instance
+		if isKmsUnsupportedError(err, instance.ServiceType) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKeyRings", "query_error", err)
+		return nil, err
+	}
+
+	return data.KeyRings, nil
+}
+
+func getKmsInstanceAllowedNetwork(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceAllowedNetwork", "connection_error", err)
+		return nil, err
+	}
+
+	policy, err := conn.GetAllowedNetworkInstancePolicy(ctx)
+	if err != nil {
+		// Instance policies are not supported by every instance
+		if isKmsUnsupportedError(err, instance.ServiceType) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceAllowedNetwork", "query_error", err)
+		return nil, err
+	}
+
+	// Public and private networks are allowed unless the policy restricts them
+	if policy == nil || policy.PolicyData.Enabled == nil || !*policy.PolicyData.Enabled ||
+		policy.PolicyData.Attributes == nil || policy.PolicyData.Attributes.AllowedNetwork == nil {
+		return "public-and-private", nil
+	}
+
+	return *policy.PolicyData.Attributes.AllowedNetwork, nil
+}
+
+func getKmsInstanceImportToken(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceImportToken", "connection_error", err)
+		return nil, err
+	}
+
+	var token kp.ImportTokenMetadata
+	if err := kmsGet(ctx, conn, "import_token", nil, &token); err != nil {
+		// No import token was created, or the instance does not support them
+		if isKmsUnsupportedError(err, instance.ServiceType) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceImportToken", "query_error", err)
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func getKmsInstanceKmipAdapters(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKmipAdapters", "connection_error", err)
+		return nil, err
+	}
+
+	var data struct {
+		Adapters []map[string]interface{} `json:"resources"`
+	}
+	if err := kmsGet(ctx, conn, "kmip_adapters", nil, &data); err != nil {
+		// KMIP adapters are not supported by every instance
+		if isKmsUnsupportedError(err, instance.ServiceType) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceKmipAdapters", "query_error", err)
+		return nil, err
+	}
+
+	return data.Adapters, nil
+}
+
+func getKmsInstanceRegistrationCount(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(kmsInstanceInfo)
+
+	// Create service connection
+	conn, err := kmsService(ctx, d, instance.ServiceType, instance.Region, instance.InstanceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceRegistrationCount", "connection_error", err)
+		return nil, err
+	}
+
+	count, err := getKmsTotalCount(ctx, conn, "keys/registrations")
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance.getKmsInstanceRegistrationCount", "query_error", err)
+		return nil, err
+	}
+
+	return count, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// Transform the key rings of an instance to their number
+func kmsKeyRingCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	keyRings, ok := d.Value.([]kp.KeyRing)
+	if !ok {
+		return nil, nil
+	}
+	return len(keyRings), nil
+}
+
+// Transform the metadata of an import token to its state
+func kmsImportTokenState(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	token, ok := d.Value.(*kp.ImportTokenMetadata)
+	if !ok || token == nil {
+		return "none", nil
+	}
+	if token.ExpirationDate != nil && token.ExpirationDate.Before(time.Now()) {
+		return "expired", nil
+	}
+	if token.RemainingRetrievals == 0 {
+		return "exhausted", nil
+	}
+	return "active", nil
+}
@@ -0,0 +1,77 @@
+package ibm
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmKmsInstancePolicy(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "ibm_kms_instance_policy",
+		Description:       "An instance policy sets the behaviour of every key of a Key Protect instance, e.g. the networks allowed to access them.",
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
+		List: &plugin.ListConfig{
+			Hydrate: listKmsInstancePolicies,
+			Tags:    serviceTags(endpointKMS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "instance_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "policy_type", Type: proto.ColumnType_STRING, Description: "The type of the policy. Possible values are allowedNetwork, allowedIP, dualAuthDelete, keyCreateImportAccess and metrics."},
+			{Name: "enabled", Type: proto.ColumnType_BOOL, Description: "Indicates whether the policy is enabled.", Transform: transform.FromField("PolicyData.Enabled")},
+			{Name: "allowed_network", Type: proto.ColumnType_STRING, Description: "The networks allowed to access the instance, public-and-private or private-only. Only set for the allowedNetwork policy.", Transform: transform.FromField("PolicyData.Attributes.AllowedNetwork")},
+			{Name: "allowed_ip", Type: proto.ColumnType_JSON, Description: "The IP addresses and subnets allowed to access the instance. Only set for the allowedIP policy.", Transform: transform.FromField("PolicyData.Attributes.AllowedIP")},
+			{Name: "create_root_key", Type: proto.ColumnType_BOOL, Description: "Indicates whether root keys can be created. Only set for the keyCreateImportAccess policy.", Transform: transform.FromField("PolicyData.Attributes.CreateRootKey")},
+			{Name: "create_standard_key", Type: proto.ColumnType_BOOL, Description: "Indicates whether standard keys can be created. Only set for the keyCreateImportAccess policy.", Transform: transform.FromField("PolicyData.Attributes.CreateStandardKey")},
+			{Name: "import_root_key", Type: proto.ColumnType_BOOL, Description: "Indicates whether root keys can be imported. Only set for the keyCreateImportAccess policy.", Transform: transform.FromField("PolicyData.Attributes.ImportRootKey")},
+			{Name: "import_standard_key", Type: proto.ColumnType_BOOL, Description: "Indicates whether standard keys can be imported. Only set for the keyCreateImportAccess policy.", Transform: transform.FromField("PolicyData.Attributes.ImportStandardKey")},
+			{Name: "enforce_token", Type: proto.ColumnType_BOOL, Description: "Indicates whether keys can only be imported with an import token. Only set for the keyCreateImportAccess policy.", Transform: transform.FromField("PolicyData.Attributes.EnforceToken")},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that created the policy."},
+			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the policy was created.", Transform: transform.FromField("CreatedAt")},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that last updated the policy."},
+			{Name: "last_updated", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the policy was last updated.", Transform: transform.FromField("UpdatedAt")},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Hydrate: plugin.HydrateFunc(getServiceInstanceID), Transform: transform.FromValue()},
+			{Name: "instance_crn", Type: proto.ColumnType_STRING, Description: "The CRN of the key protect instance.", Hydrate: plugin.HydrateFunc(getServiceInstanceCRN), Transform: transform.FromValue()},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Hydrate: plugin.HydrateFunc(getServiceType), Transform: transform.FromValue()},
+
+			// Standard columns
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this instance policy."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("PolicyType"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listKmsInstancePolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_instance_policy.listKmsInstancePolicies")
+	if err != nil || conn == nil {
+		return nil, err
+	}
+
+	// Only the policies which were ever set are returned
+	policies, err := conn.GetInstancePolicies(ctx)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_instance_policy.listKmsInstancePolicies", "query_error", err)
+		return nil, err
+	}
+	for _, i := range policies {
+		d.StreamListItem(ctx, i)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestKmsInstancePolicy(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	cloud.KMS.fixture("GET /api/v2/instance/policies", "kms/instance_policies.json")
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_kms_instance_policy", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	policies := rowsByColumn(t, rows, "policy_type")
+	assertColumns(t, policies["allowedNetwork"], map[string]interface{}{
+		"account_id":      testAccountID,
+		"enabled":         true,
+		"allowed_network": "private-only",
+		"creation_date":   "2023-02-01T11:00:00Z",
+		"instance_id":     testKmsInstanceID,
+		"instance_crn":    "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d::",
+		"kms_type":        "kms",
+		"region":          "us-south",
+		"title":           "allowedNetwork",
+	})
+	assertColumns(t, policies["dualAuthDelete"], map[string]interface{}{
+		"enabled":         false,
+		"allowed_network": nil,
+	})
+}
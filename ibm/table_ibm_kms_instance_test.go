@@ -0,0 +1,124 @@
+package ibm
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testHpcsInstanceID = "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b"
+
+// serveKmsInstance serves the instance APIs of a Key Protect or Hyper Protect
+// Crypto Services instance
+func serveKmsInstance(s *fakeService) {
+	s.fixture("GET /api/v2/keys", "kms/keys.json")
+	s.fixture("GET /api/v2/keys/registrations", "kms/registrations.json")
+}
+
+// notImplementedHandler rejects the requests to an API which the instance does not implement
+func notImplementedHandler(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusNotImplemented, map[string]interface{}{
+		"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1},
+		"resources": []map[string]string{{"errorMsg": "Not Implemented"}},
+	})
+}
+
+// newFakeHpcsCloud returns a cloud with a Key Protect instance and a Hyper
+// Protect Crypto Services instance, and the endpoint of the HPCS instance
+func newFakeHpcsCloud(t *testing.T) (*fakeCloud, *fakeService) {
+	t.Helper()
+
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/kms_hpcs_instances.json")
+
+	// Every HPCS instance has its own endpoint
+	hpcs := newFakeService(t)
+	cloud.ResourceController.handle("GET /v2/resource_instances/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testHpcsInstanceID {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Instance not found"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bytes.ReplaceAll(readFixture(t, "resource_controller/hpcs_instance.json"), []byte("{{endpoint}}"), []byte(hpcs.URL)))
+	})
+
+	serveKmsInstance(cloud.KMS)
+	cloud.KMS.fixture("GET /api/v2/key_rings", "kms/key_rings.json")
+	cloud.KMS.fixture("GET /api/v2/instance/policies", "kms/instance_policies.json")
+	cloud.KMS.fixture("GET /api/v2/import_token", "kms/import_token.json")
+	cloud.KMS.fixture("GET /api/v2/kmip_adapters", "kms/kmip_adapters.json")
+
+	// HPCS rejects the requests to the Key Protect APIs it does not implement
+	serveKmsInstance(hpcs)
+	hpcs.handle("GET /api/v2/kmip_adapters", notImplementedHandler)
+	hpcs.handle("GET /api/v2/key_rings", notImplementedHandler)
+	hpcs.handle("GET /api/v2/instance/policies", notImplementedHandler)
+
+	return cloud, hpcs
+}
+
+func TestKmsInstance(t *testing.T) {
+	cloud, hpcs := newFakeHpcsCloud(t)
+	hpcs.handle("GET /api/v2/import_token", notImplementedHandler)
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_kms_instance", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	instances := rowsByColumn(t, rows, "instance_id")
+	assertColumns(t, instances[testKmsInstanceID], map[string]interface{}{
+		"account_id":         testAccountID,
+		"kms_type":           "kms",
+		"region":             "us-south",
+		"key_count":          2,
+		"key_ring_count":     2,
+		"allowed_network":    "private-only",
+		"registration_count": 1,
+		"import_token_state": "active",
+		"import_token": map[string]interface{}{
+			"id":                   "4f5e6d7c-8b9a-4e0d-9c1b-2a3f4e5d6c7b",
+			"creationDate":         "2023-02-02T09:00:00Z",
+			"expirationDate":       "2123-02-02T09:10:00Z",
+			"maxAllowedRetrievals": 5,
+			"remainingRetrievals":  4,
+		},
+	})
+	if adapters, ok := instances[testKmsInstanceID]["kmip_adapters"].([]interface{}); !ok || len(adapters) != 1 {
+		t.Errorf("kmip_adapters = %v, want 1 adapter", instances[testKmsInstanceID]["kmip_adapters"])
+	}
+	assertColumns(t, instances[testHpcsInstanceID], map[string]interface{}{
+		"kms_type":           "hs-crypto",
+		"region":             "us-south",
+		"key_count":          2,
+		"key_ring_count":     nil,
+		"key_rings":          nil,
+		"allowed_network":    nil,
+		"import_token_state": "none",
+		"import_token":       nil,
+		"kmip_adapters":      nil,
+	})
+
+	// The requests of the HPCS instance are sent to its own endpoint
+	if got := len(hpcs.requestsTo("/api/v2/import_token")); got != 1 {
+		t.Errorf("got %d requests to the HPCS endpoint, want 1", got)
+	}
+}
+
+func TestKmsInstanceBadRequest(t *testing.T) {
+	// Only 501 Not Implemented means that HPCS does not support an API
+	cloud, hpcs := newFakeHpcsCloud(t)
+	hpcs.handle("GET /api/v2/import_token", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1},
+			"resources": []map[string]string{{"errorMsg": "Bad Request: The request is invalid"}},
+		})
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	_, err := conn.query("ibm_kms_instance", map[string]interface{}{"instance_id": testHpcsInstanceID}, "instance_id", "import_token")
+	if err == nil || !strings.Contains(err.Error(), "The request is invalid") {
+		t.Fatalf("got error %v, want the bad request error", err)
+	}
+}
@@ -2,6 +2,8 @@ package ibm
 
 import (
 	"context"
+	"net/url"
+	"strconv"
 	"strings"
 
 	kp "github.com/IBM/keyprotect-go-client"
@@ -16,9 +18,10 @@ func tableIbmKmsKey(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:              "ibm_kms_key",
 		Description:       "A key is a named object containing one or more key versions, along with metadata for the key.",
-		GetMatrixItemFunc: BuildServiceInstanceList,
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
 		List: &plugin.ListConfig{
 			Hydrate: listKmsKeys,
+			Tags:    serviceTags(endpointKMS),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "instance_id",
@@ -28,12 +31,28 @@ func tableIbmKmsKey(ctx context.Context) *plugin.Table {
 					Name:    "key_ring_id",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "state",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "extractable",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "deleted",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getKmsKey,
+			Tags:       serviceTags(endpointKMS),
 			KeyColumns: plugin.SingleColumn("id"),
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getKmsKeyRotationPolicy, Tags: serviceTags(endpointKMS)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "name", Type: proto.ColumnType_STRING, Description: "A human-readable name assigned to your key for convenience."},
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key."},
@@ -41,7 +60,8 @@ func tableIbmKmsKey(ctx context.Context) *plugin.Table {
 			{Name: "type", Type: proto.ColumnType_STRING, Description: "Specifies the MIME type that represents the key resource."},
 			{Name: "state", Type: proto.ColumnType_STRING, Description: "The key state based on NIST SP 800-57. States are integers and correspond to the Pre-activation = 0, Active = 1, Suspended = 2, Deactivated = 3, and Destroyed = 5 values."},
 			{Name: "imported", Type: proto.ColumnType_BOOL, Description: "Indicates whether the key was originally imported or generated in Key Protect."},
-			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Hydrate: plugin.HydrateFunc(getServiceInstanceID)},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Transform: transform.FromField("CRN").Transform(crnServiceInstance)},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Transform: transform.FromField("CRN").Transform(crnServiceName)},
 			{Name: "algorithm_type", Type: proto.ColumnType_STRING, Description: "Specifies the key algorithm."},
 			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The timestamp when the key material was created."},
 			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that created the key."},
@@ -63,10 +83,10 @@ func tableIbmKmsKey(ctx context.Context) *plugin.Table {
 			{Name: "rotation_policy", Type: proto.ColumnType_JSON, Description: "Key rotation policy.", Hydrate: getKmsKeyRotationPolicy, Transform: transform.FromValue()},
 
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this key."},
+			{Name: "region", Type: proto.ColumnType_STRING, Transform: transform.FromField("CRN").Transform(crnRegion), Description: "The region of this key."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -76,34 +96,14 @@ func tableIbmKmsKey(ctx context.Context) *plugin.Table {
 func listKmsKeys(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("listKmsKeys")
 
-	instanceID := d.EqualsQualString("instance_id")
-	serviceType := d.EqualsQualString("service_type")
-
-	// Invalid service type
-	if serviceType != "kms" {
-		return nil, nil
-	}
-
-	// Return if specified instanceID not matched
-	if d.EqualsQuals["instance_id"] != nil && d.EqualsQuals["instance_id"].GetStringValue() != instanceID {
-		return nil, nil
-	}
-
-	// Create service connection
-	conn, err := kmsService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_kms_key.listKmsKeys", "connection_error", err)
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key.listKmsKeys")
+	if err != nil || conn == nil {
 		return nil, err
 	}
-	conn.Config.InstanceID = instanceID
 
-	// Additional filters
-	if d.EqualsQuals["key_ring_id"] != nil {
-		conn.Config.KeyRing = d.EqualsQuals["key_ring_id"].GetStringValue()
-	}
-
-	// Retrieve the list of keys for your account.
-	maxResult := int64(100)
+	// 5000 is the maximum number of keys per page
+	maxResult := int64(5000)
 
 	// Reduce the basic request limit down if the user has only requested a small number of rows
 	limit := d.QueryContext.Limit
@@ -113,21 +113,51 @@ func listKmsKeys(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		}
 	}
 
-	data, err := conn.GetKeys(ctx, int(maxResult), 0)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_kms_key.listKmsKeys", "query_error", err)
-		if strings.Contains(err.Error(), "key_ring does not exist") {
+	// Additional filters, which the client transport adds to the request
+	opts := &kmsRequestOptions{
+		KeyRing: d.EqualsQualString("key_ring_id"),
+		Query:   url.Values{},
+	}
+	if d.EqualsQuals["state"] != nil {
+		state := d.EqualsQualString("state")
+		// No keys in an unknown state
+		if _, err := strconv.Atoi(state); err != nil {
 			return nil, nil
 		}
-		return nil, err
+		opts.Query.Set("state", state)
+	} else if d.EqualsQuals["deleted"] != nil {
+		// Deleted keys are in the destroyed state, and are only listed if requested
+		if d.EqualsQuals["deleted"].GetBoolValue() {
+			opts.Query.Set("state", "5")
+		} else {
+			opts.Query.Set("state", "0,1,2,3")
+		}
+	}
+	if d.EqualsQuals["extractable"] != nil {
+		opts.Query.Set("extractable", strconv.FormatBool(d.EqualsQuals["extractable"].GetBoolValue()))
 	}
-	for _, i := range data.Keys {
-		d.StreamListItem(ctx, i)
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
+	err = kmsListPages(int(maxResult), func(offset int) (int, error) {
+		data, err := conn.GetKeys(withKmsRequestOptions(ctx, opts), int(maxResult), offset)
+		if err != nil {
+			return 0, err
+		}
+		for _, i := range data.Keys {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return 0, nil
+			}
+		}
+		return len(data.Keys), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_key.listKmsKeys", "query_error", err)
+		if strings.Contains(err.Error(), "key_ring does not exist") {
 			return nil, nil
 		}
+		return nil, err
 	}
 	return nil, nil
 }
@@ -135,13 +165,6 @@ func listKmsKeys(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 //// HYDRATE FUNCTIONS
 
 func getKmsKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	instanceID := d.EqualsQualString("instance_id")
-	serviceType := d.EqualsQualString("service_type")
-
-	// Invalid service type
-	if serviceType != "kms" {
-		return nil, nil
-	}
 	id := d.EqualsQuals["id"].GetStringValue()
 
 	// No inputs
@@ -149,17 +172,19 @@ func getKmsKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, nil
 	}
 
-	// Create service connection
-	conn, err := kmsService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_kms_key.listKmsKeys", "connection_error", err)
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key.getKmsKey")
+	if err != nil || conn == nil {
 		return nil, err
 	}
-	conn.Config.InstanceID = instanceID
 
 	data, err := conn.GetKeyMetadata(ctx, id)
 	if err != nil {
-		plugin.Logger(ctx).Error("ibm_kms_key.listKmsKeys", "query_error", err)
+		// Every instance is queried, and the key belongs to one of them
+		if isKmsNotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_key.getKmsKey", "query_error", err)
 		return nil, err
 	}
 
@@ -168,16 +193,17 @@ func getKmsKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 
 func getKmsKeyRotationPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	instanceID := d.EqualsQualString("instance_id")
+	region := d.EqualsQualString("region")
+	serviceType := d.EqualsQualString("service_type")
 
 	id := h.Item.(kp.Key).ID
 
 	// Create service connection
-	conn, err := kmsService(ctx, d)
+	conn, err := kmsService(ctx, d, serviceType, region, instanceID)
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_kms_key.getKmsKeyRotationPolicy", "connection_error", err)
 		return nil, err
 	}
-	conn.Config.InstanceID = instanceID
 
 	data, err := conn.GetRotationPolicy(ctx, id)
 	if err != nil {
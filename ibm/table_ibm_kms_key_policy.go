@@ -0,0 +1,117 @@
+package ibm
+
+import (
+	"context"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmKmsKeyPolicy(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "ibm_kms_key_policy",
+		Description:       "A key policy sets the rotation or dual authorization delete behaviour of a key.",
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
+		List: &plugin.ListConfig{
+			Hydrate: listKmsKeyPolicies,
+			Tags:    serviceTags(endpointKMS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "instance_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "key_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "key_id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key.", Transform: transform.FromField("KeyID")},
+			{Name: "key_name", Type: proto.ColumnType_STRING, Description: "The name of the key."},
+			{Name: "policy_type", Type: proto.ColumnType_STRING, Description: "The type of the policy. Possible values are rotation and dualAuthDelete."},
+			{Name: "key_crn", Type: proto.ColumnType_STRING, Description: "The Cloud Resource Name (CRN) of the key.", Transform: transform.FromField("KeyCRN")},
+			{Name: "dual_auth_delete_enabled", Type: proto.ColumnType_BOOL, Description: "Indicates whether the deletion of the key requires the authorization of two users.", Transform: transform.FromField("Policy.DualAuth.Enabled")},
+			{Name: "rotation_interval_month", Type: proto.ColumnType_INT, Description: "The number of months between automatic rotations of the key.", Transform: transform.FromField("Policy.Rotation.Interval")},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that created the policy.", Transform: transform.FromField("Policy.CreatedBy")},
+			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the policy was created.", Transform: transform.FromField("Policy.CreatedAt")},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that last updated the policy.", Transform: transform.FromField("Policy.UpdatedBy")},
+			{Name: "last_update_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the policy was last updated.", Transform: transform.FromField("Policy.UpdatedAt")},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Transform: transform.FromField("KeyCRN").Transform(crnServiceInstance)},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Transform: transform.FromField("KeyCRN").Transform(crnServiceName)},
+
+			// Standard columns
+			{Name: "region", Type: proto.ColumnType_STRING, Transform: transform.FromField("KeyCRN").Transform(crnRegion), Description: "The region of this key policy."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("PolicyType"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+type keyPolicyInfo struct {
+	Policy     kp.Policy
+	PolicyType string
+	KeyID      string
+	KeyName    string
+	KeyCRN     string
+}
+
+//// LIST FUNCTION
+
+func listKmsKeyPolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key_policy.listKmsKeyPolicies")
+	if err != nil || conn == nil {
+		return nil, err
+	}
+
+	// Only the policies of a single key are requested if the key is specified
+	keys, err := getKmsKeys(ctx, conn, d.EqualsQualString("key_id"))
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_key_policy.listKmsKeyPolicies", "query_error", err)
+		return nil, err
+	}
+
+	for _, key := range keys {
+		policies, err := conn.GetPolicies(ctx, key.ID)
+		if err != nil {
+			// The key was deleted after it was listed
+			if isKmsNotFoundError(err) {
+				continue
+			}
+			plugin.Logger(ctx).Error("ibm_kms_key_policy.listKmsKeyPolicies", "query_error", err)
+			return nil, err
+		}
+		for _, policy := range policies {
+			d.StreamListItem(ctx, keyPolicyInfo{
+				Policy:     policy,
+				PolicyType: kmsKeyPolicyType(policy),
+				KeyID:      key.ID,
+				KeyName:    key.Name,
+				KeyCRN:     key.CRN,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// kmsKeyPolicyType returns the type of a key policy. The type of the API is
+// the MIME type of every policy, so the type is given by the policy set.
+func kmsKeyPolicyType(policy kp.Policy) string {
+	switch {
+	case policy.Rotation != nil:
+		return "rotation"
+	case policy.DualAuth != nil:
+		return kp.DualAuthDelete
+	}
+	return ""
+}
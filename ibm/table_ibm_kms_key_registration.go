@@ -0,0 +1,136 @@
+package ibm
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmKmsKeyRegistration(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "ibm_kms_key_registration",
+		Description:       "A key registration links a root key to a cloud resource it protects, such as a Cloud Object Storage bucket.",
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
+		List: &plugin.ListConfig{
+			Hydrate: listKmsKeyRegistrations,
+			Tags:    serviceTags(endpointKMS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "instance_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "key_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "resource_crn",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "key_id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key.", Transform: transform.FromField("Registration.KeyID")},
+			{Name: "key_crn", Type: proto.ColumnType_STRING, Description: "The Cloud Resource Name (CRN) of the key.", Transform: transform.FromField("KeyCRN")},
+			{Name: "resource_crn", Type: proto.ColumnType_STRING, Description: "The Cloud Resource Name (CRN) of the resource protected by the key.", Transform: transform.FromField("Registration.ResourceCrn")},
+			{Name: "prevent_key_deletion", Type: proto.ColumnType_BOOL, Description: "Indicates whether the key cannot be deleted while the registration exists.", Transform: transform.FromField("Registration.PreventKeyDeletion")},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "A description of the registration.", Transform: transform.FromField("Registration.Description")},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that created the registration.", Transform: transform.FromField("Registration.CreatedBy")},
+			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the registration was created.", Transform: transform.FromField("Registration.CreationDate")},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The unique identifier for the resource that last updated the registration.", Transform: transform.FromField("Registration.UpdatedBy")},
+			{Name: "last_updated", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the registration was last updated.", Transform: transform.FromField("Registration.LastUpdateDate")},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Hydrate: plugin.HydrateFunc(getServiceInstanceID), Transform: transform.FromValue()},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Hydrate: plugin.HydrateFunc(getServiceType), Transform: transform.FromValue()},
+			{Name: "key_version", Type: proto.ColumnType_JSON, Description: "The version of the key used to protect the resource.", Transform: transform.FromField("Registration.KeyVersion")},
+
+			// Standard columns
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this key registration."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Registration.ResourceCrn"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+type keyRegistrationInfo struct {
+	Registration kp.Registration
+	KeyCRN       string
+}
+
+//// LIST FUNCTION
+
+func listKmsKeyRegistrations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key_registration.listKmsKeyRegistrations")
+	if err != nil || conn == nil {
+		return nil, err
+	}
+
+	instanceCRN := d.EqualsQualString("instance_crn")
+
+	// The registrations of every key are listed, unless a key is specified
+	path := "keys/registrations"
+	if keyID := d.EqualsQualString("key_id"); keyID != "" {
+		path = "keys/" + url.PathEscape(keyID) + "/registrations"
+	}
+
+	// 5000 is the maximum number of registrations per page
+	limit := 5000
+
+	err = kmsListPages(limit, func(offset int) (int, error) {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset))
+
+		// Additional filters
+		if value := d.EqualsQualString("resource_crn"); value != "" {
+			query.Set("urlEncodedResourceCRNQuery", value)
+		}
+
+		var page struct {
+			Registrations []kp.Registration `json:"resources"`
+		}
+		if err := kmsGet(ctx, conn, path, query, &page); err != nil {
+			return 0, err
+		}
+
+		for _, i := range page.Registrations {
+			d.StreamListItem(ctx, keyRegistrationInfo{
+				Registration: i,
+				KeyCRN:       kmsKeyCRN(instanceCRN, i.KeyID),
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return 0, nil
+			}
+		}
+		return len(page.Registrations), nil
+	})
+	if err != nil {
+		// The key belongs to another instance
+		if isKmsNotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("ibm_kms_key_registration.listKmsKeyRegistrations", "query_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// kmsKeyCRN returns the CRN of a key from the CRN of its instance, which ends
+// with an empty resource type and resource, e.g.
+// crn:v1:bluemix:public:kms:us-south:a/<account>:<instance>::
+func kmsKeyCRN(instanceCRN string, keyID string) string {
+	if instanceCRN == "" || keyID == "" {
+		return ""
+	}
+	return strings.TrimSuffix(instanceCRN, "::") + ":key:" + keyID
+}
@@ -0,0 +1,44 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestKmsKeyRegistration(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	cloud.KMS.fixture("GET /api/v2/keys/registrations", "kms/registrations.json")
+	cloud.KMS.fixture("GET /api/v2/keys/{id}/registrations", "kms/registrations.json")
+	conn := newTestConnection(t, cloud.config())
+
+	expected := map[string]interface{}{
+		"account_id":           testAccountID,
+		"key_id":               testKmsRootKeyID,
+		"key_crn":              "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:key:02fd6835-6001-4482-a892-13bd2085f75d",
+		"resource_crn":         "crn:v1:bluemix:public:cloud-object-storage:global:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a:bucket:my-bucket",
+		"prevent_key_deletion": true,
+		"creation_date":        "2023-02-03T14:00:00Z",
+		"instance_id":          testKmsInstanceID,
+		"kms_type":             "kms",
+		"region":               "us-south",
+	}
+
+	t.Run("list", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_kms_key_registration", nil)
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+	})
+
+	t.Run("list by key", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_kms_key_registration", map[string]interface{}{"key_id": testKmsRootKeyID})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], expected)
+
+		if requests := cloud.KMS.requestsTo("/api/v2/keys/" + testKmsRootKeyID + "/registrations"); len(requests) != 1 {
+			t.Errorf("got %d requests for the registrations of the key, want 1", len(requests))
+		}
+	})
+}
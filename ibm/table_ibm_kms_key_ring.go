@@ -14,9 +14,10 @@ func tableIbmKmsKeyRing(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:              "ibm_kms_key_ring",
 		Description:       "A key ring is a collection of leys in an IBM cloud location.",
-		GetMatrixItemFunc: BuildServiceInstanceList,
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
 		List: &plugin.ListConfig{
 			Hydrate: listKmsKeyRings,
+			Tags:    serviceTags(endpointKMS),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "instance_id",
@@ -26,12 +27,13 @@ func tableIbmKmsKeyRing(ctx context.Context) *plugin.Table {
 		},
 		Columns: commonColumns([]*plugin.Column{
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key ring."},
-			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Hydrate: plugin.HydrateFunc(getServiceInstanceID)},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Hydrate: plugin.HydrateFunc(getServiceInstanceID), Transform: transform.FromValue()},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Hydrate: plugin.HydrateFunc(getServiceType), Transform: transform.FromValue()},
 			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date and time when the key ring was created."},
 			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The creator of the key ring."},
 
 			// Standard columns
-			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Description: "The region of this key ring."},
+			{Name: "region", Type: proto.ColumnType_STRING, Hydrate: plugin.HydrateFunc(getRegion), Transform: transform.FromValue(), Description: "The region of this key ring."},
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: resourceInterfaceDescription("title")},
 		}),
 	}
@@ -42,26 +44,11 @@ func tableIbmKmsKeyRing(ctx context.Context) *plugin.Table {
 func listKmsKeyRings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("listKmsKeyRings")
 
-	instanceID := d.EqualsQualString("instance_id")
-	serviceType := d.EqualsQualString("service_type")
-
-	// Invalid service type
-	if serviceType != "kms" {
-		return nil, nil
-	}
-
-	// Return if specified instanceID not matched
-	if d.EqualsQuals["instance_id"] != nil && d.EqualsQuals["instance_id"].GetStringValue() != instanceID {
-		return nil, nil
-	}
-
-	// Create service connection
-	conn, err := kmsService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_kms_key_ring.listKmsKeyRings", "connection_error", err)
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key_ring.listKmsKeyRings")
+	if err != nil || conn == nil {
 		return nil, err
 	}
-	conn.Config.InstanceID = instanceID
 
 	data, err := conn.GetKeyRings(ctx)
 	if err != nil {
@@ -0,0 +1,176 @@
+package ibm
+
+import (
+	"net/http"
+	"testing"
+)
+
+const (
+	testKmsInstanceID = "5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d"
+	testKmsRootKeyID  = "02fd6835-6001-4482-a892-13bd2085f75d"
+)
+
+// newFakeKmsCloud returns the fake services of an account with a Key Protect
+// instance in us-south, and an Object Storage instance which must not be queried
+func newFakeKmsCloud(t *testing.T) *fakeCloud {
+	t.Helper()
+
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/resource_instances.json")
+	cloud.KMS.fixture("GET /api/v2/keys", "kms/keys.json")
+	cloud.KMS.fixture("GET /api/v2/key_rings", "kms/key_rings.json")
+	cloud.KMS.handle("GET /api/v2/keys/{id}/metadata", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testKmsRootKeyID {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{
+				"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1},
+				"resources": []map[string]string{{"errorMsg": "Not Found: The resource could not be found"}},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "kms/key.json"))
+	})
+	cloud.KMS.handle("GET /api/v2/keys/{id}/policies", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testKmsRootKeyID {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 0},
+				"resources": []interface{}{},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "kms/rotation_policy.json"))
+	})
+
+	return cloud
+}
+
+func TestKmsKey(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	conn := newTestConnection(t, cloud.config())
+
+	rootKey := map[string]interface{}{
+		"account_id":       testAccountID,
+		"id":               testKmsRootKeyID,
+		"name":             "root-key",
+		"crn":              "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:key:02fd6835-6001-4482-a892-13bd2085f75d",
+		"instance_id":      testKmsInstanceID,
+		"kms_type":         "kms",
+		"state":            "1",
+		"extractable":      false,
+		"imported":         false,
+		"algorithm_type":   "AES",
+		"creation_date":    "2023-02-02T09:00:00Z",
+		"last_rotate_date": "2023-05-02T09:00:00Z",
+		"key_ring_id":      "default",
+		"region":           "us-south",
+		"title":            "root-key",
+		"rotation_policy": map[string]interface{}{
+			"createdBy":      "IBMid-270002ABCD",
+			"creationDate":   "2023-02-02T09:05:00Z",
+			"crn":            "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:policy:0d2a5f3e-6b7c-4d8e-9f0a-1b2c3d4e5f6a",
+			"lastUpdateDate": "2023-02-02T09:05:00Z",
+			"rotation":       map[string]interface{}{"interval_month": 3},
+			"type":           "application/vnd.ibm.kms.policy+json",
+			"updatedBy":      "IBMid-270002ABCD",
+		},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_kms_key", nil)
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, want 2", len(rows))
+		}
+		keys := rowsByColumn(t, rows, "name")
+		assertColumns(t, keys["root-key"], rootKey)
+		assertColumns(t, keys["standard-key"], map[string]interface{}{
+			"id":              "8c0a1b2d-3e4f-4a5b-9c6d-7e8f9a0b1c2d",
+			"instance_id":     testKmsInstanceID,
+			"extractable":     true,
+			"imported":        true,
+			"key_ring_id":     "app-keys",
+			"rotation_policy": nil,
+		})
+
+		// Every request is sent to the Key Protect instance
+		for _, r := range cloud.KMS.requestsTo("/api/v2/keys") {
+			if got := r.Header.Get("bluemix-instance"); got != testKmsInstanceID {
+				t.Errorf("bluemix-instance = %q, want %q", got, testKmsInstanceID)
+			}
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		rows := conn.mustQuery("ibm_kms_key", map[string]interface{}{"id": testKmsRootKeyID})
+		if len(rows) != 1 {
+			t.Fatalf("got %d rows, want 1", len(rows))
+		}
+		assertColumns(t, rows[0], rootKey)
+	})
+
+	t.Run("list with filters", func(t *testing.T) {
+		conn.mustQuery("ibm_kms_key", map[string]interface{}{"key_ring_id": "app-keys", "extractable": true}, "id")
+
+		requests := cloud.KMS.requestsTo("/api/v2/keys")
+		last := requests[len(requests)-1]
+		if got := last.Header.Get("x-kms-key-ring"); got != "app-keys" {
+			t.Errorf("x-kms-key-ring = %q, want app-keys", got)
+		}
+		if got := last.URL.Query().Get("extractable"); got != "true" {
+			t.Errorf("extractable = %q, want true", got)
+		}
+	})
+}
+
+func TestKmsKeyRing(t *testing.T) {
+	cloud := newFakeKmsCloud(t)
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_kms_key_ring", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	keyRings := rowsByColumn(t, rows, "id")
+	assertColumns(t, keyRings["app-keys"], map[string]interface{}{
+		"account_id":    testAccountID,
+		"instance_id":   testKmsInstanceID,
+		"kms_type":      "kms",
+		"creation_date": "2023-03-10T12:00:00Z",
+		"created_by":    "IBMid-270002ABCD",
+		"region":        "us-south",
+		"title":         "app-keys",
+	})
+}
+
+// TestKmsKeyGetSeveralInstances gets a key by id in an account with two
+// instances, where the instance which does not hold the key returns 404
+func TestKmsKeyGetSeveralInstances(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/kms_instances.json")
+	cloud.KMS.handle("GET /api/v2/keys/{id}/metadata", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("bluemix-instance") != testKmsInstanceID || r.PathValue("id") != testKmsRootKeyID {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{
+				"metadata":  map[string]interface{}{"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1},
+				"resources": []map[string]string{{"errorMsg": "Not Found: The resource could not be found"}},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(readFixture(t, "kms/key.json"))
+	})
+	conn := newTestConnection(t, cloud.config())
+
+	rows := conn.mustQuery("ibm_kms_key", map[string]interface{}{"id": testKmsRootKeyID}, "id", "instance_id")
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"id":          testKmsRootKeyID,
+		"instance_id": testKmsInstanceID,
+	})
+
+	// Both instances were asked for the key
+	if got := len(cloud.KMS.requestsTo("/api/v2/keys/" + testKmsRootKeyID + "/metadata")); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
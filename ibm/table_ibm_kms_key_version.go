@@ -0,0 +1,107 @@
+package ibm
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmKmsKeyVersion(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:              "ibm_kms_key_version",
+		Description:       "A key version is a version of the key material of a key, created when the key is created or rotated.",
+		GetMatrixItemFunc: BuildServiceInstanceListByType(kmsTypeKeyProtect, kmsTypeHPCS),
+		List: &plugin.ListConfig{
+			Hydrate: listKmsKeyVersions,
+			Tags:    serviceTags(endpointKMS),
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "instance_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "key_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{Name: "id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key version."},
+			{Name: "key_id", Type: proto.ColumnType_STRING, Description: "An unique identifier of the key.", Transform: transform.FromField("KeyID")},
+			{Name: "key_name", Type: proto.ColumnType_STRING, Description: "The name of the key."},
+			{Name: "key_crn", Type: proto.ColumnType_STRING, Description: "The Cloud Resource Name (CRN) of the key.", Transform: transform.FromField("KeyCRN")},
+			{Name: "creation_date", Type: proto.ColumnType_TIMESTAMP, Description: "The date when the key version was created, i.e. when the key was created or rotated."},
+			{Name: "current", Type: proto.ColumnType_BOOL, Description: "Indicates whether the version is the current version of the key, which is used to wrap new data encryption keys."},
+			{Name: "key_state", Type: proto.ColumnType_STRING, Description: "The state of the key. States are integers and correspond to the Pre-activation = 0, Active = 1, Suspended = 2, Deactivated = 3, and Destroyed = 5 values."},
+			{Name: "instance_id", Type: proto.ColumnType_STRING, Description: "The key protect instance GUID.", Transform: transform.FromField("KeyCRN").Transform(crnServiceInstance)},
+			{Name: "kms_type", Type: proto.ColumnType_STRING, Description: "The type of the key management service, kms for Key Protect or hs-crypto for Hyper Protect Crypto Services.", Transform: transform.FromField("KeyCRN").Transform(crnServiceName)},
+
+			// Standard columns
+			{Name: "region", Type: proto.ColumnType_STRING, Transform: transform.FromField("KeyCRN").Transform(crnRegion), Description: "The region of this key version."},
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+type keyVersionInfo struct {
+	ID           string
+	CreationDate *time.Time
+	KeyID        string
+	KeyName      string
+	KeyCRN       string
+	KeyState     int
+	Current      bool
+}
+
+//// LIST FUNCTION
+
+func listKmsKeyVersions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection, unless the matrix item is not a requested key management instance
+	conn, err := kmsMatrixService(ctx, d, "ibm_kms_key_version.listKmsKeyVersions")
+	if err != nil || conn == nil {
+		return nil, err
+	}
+
+	// Only the versions of a single key are requested if the key is specified
+	keys, err := getKmsKeys(ctx, conn, d.EqualsQualString("key_id"))
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_kms_key_version.listKmsKeyVersions", "query_error", err)
+		return nil, err
+	}
+
+	for _, key := range keys {
+		versions, err := getKmsKeyVersions(ctx, conn, key.ID)
+		if err != nil {
+			// The key was deleted after it was listed
+			if isKmsNotFoundError(err) {
+				continue
+			}
+			plugin.Logger(ctx).Error("ibm_kms_key_version.listKmsKeyVersions", "query_error", err)
+			return nil, err
+		}
+		for _, version := range versions {
+			d.StreamListItem(ctx, keyVersionInfo{
+				ID:           version.ID,
+				CreationDate: version.CreationDate,
+				KeyID:        key.ID,
+				KeyName:      key.Name,
+				KeyCRN:       key.CRN,
+				KeyState:     key.State,
+				Current:      key.KeyVersion != nil && strings.EqualFold(key.KeyVersion.ID, version.ID),
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
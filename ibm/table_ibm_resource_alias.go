@@ -0,0 +1,170 @@
+package ibm
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmResourceAlias(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_resource_alias",
+		Description: "A resource alias makes a resource instance available in another environment, such as a Cloud Foundry space or a Code Engine project.",
+		List: &plugin.ListConfig{
+			Hydrate: listResourceAlias,
+			Tags:    serviceTags(endpointResourceController),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+				{Name: "guid", Require: plugin.Optional},
+				{Name: "resource_group_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_instance_id", Require: plugin.Optional},
+				{Name: "region_instance_id", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getResourceAlias,
+			Tags:       serviceTags(endpointResourceController),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The human-readable name of the alias."},
+			{Name: "id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: "The ID associated with the alias."},
+			{Name: "guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("GUID"), Description: "When you create a new alias, a globally unique identifier (GUID) is assigned."},
+			{Name: "crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("CRN"), Description: "The full Cloud Resource Name (CRN) associated with the alias."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The state of the alias."},
+			{Name: "resource_instance_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceInstanceID"), Description: "The ID of the resource instance the alias was created for, which is the CRN of the instance."},
+			{Name: "target_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("TargetCRN"), Description: "The CRN of the target namespace of the alias, e.g. a Cloud Foundry space."},
+			// Other columns
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(ensureTimestamp), Description: "The date when the alias was created."},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The subject who created the alias."},
+			{Name: "deleted_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DeletedAt").Transform(ensureTimestamp), Description: "The date when the alias was deleted."},
+			{Name: "deleted_by", Type: proto.ColumnType_STRING, Description: "The subject who deleted the alias."},
+			{Name: "migrated", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the alias was migrated from a previous CF instance."},
+			{Name: "region_instance_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("RegionInstanceCRN"), Description: "The CRN of the instance in the specific target environment."},
+			{Name: "region_instance_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("RegionInstanceID"), Description: "The ID of the instance in the specific target environment."},
+			{Name: "resource_bindings_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceBindingsURL"), Description: "The relative path to the resource bindings of the alias."},
+			{Name: "resource_group_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupID"), Description: "The short ID of the resource group."},
+			{Name: "resource_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceID"), Description: "The unique ID of the offering."},
+			{Name: "resource_instance_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceInstanceURL"), Description: "The relative path to the resource instance the alias was created for."},
+			{Name: "resource_keys_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceKeysURL"), Description: "The relative path to the resource keys of the alias."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(ensureTimestamp), Description: "The date when the alias was last updated."},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The subject who updated the alias."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "When you created a new alias, a relative URL path is created identifying the location of the alias."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listResourceAlias(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_alias.listResourceAlias", "connection_error", err)
+		return nil, err
+	}
+
+	// 100 is the maximum number of results per page
+	limit := int64(100)
+	if d.QueryContext.Limit != nil && *d.QueryContext.Limit < limit {
+		limit = *d.QueryContext.Limit
+	}
+
+	opts := &resourcecontrollerv2.ListResourceAliasesOptions{
+		Limit: core.Int64Ptr(limit),
+	}
+
+	// Additional filters
+	if value := d.EqualsQualString("name"); value != "" {
+		opts.SetName(value)
+	}
+	if value := d.EqualsQualString("guid"); value != "" {
+		opts.SetGUID(value)
+	}
+	if value := d.EqualsQualString("resource_group_id"); value != "" {
+		opts.SetResourceGroupID(value)
+	}
+	if value := d.EqualsQualString("resource_id"); value != "" {
+		opts.SetResourceID(value)
+	}
+	if value := d.EqualsQualString("resource_instance_id"); value != "" {
+		opts.SetResourceInstanceID(value)
+	}
+	if value := d.EqualsQualString("region_instance_id"); value != "" {
+		opts.SetRegionInstanceID(value)
+	}
+
+	for {
+		result, resp, err := conn.ListResourceAliasesWithContext(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_alias.listResourceAlias", "query_error", err, "resp", resp)
+			return nil, err
+		}
+
+		for _, i := range result.Resources {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		// The next page is requested with the start token of the next URL
+		start, err := core.GetQueryParam(result.NextURL, "start")
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_alias.listResourceAlias", "next_url_error", err)
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		opts.Start = start
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getResourceAlias(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// No inputs
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_alias.getResourceAlias", "connection_error", err)
+		return nil, err
+	}
+
+	result, resp, err := conn.GetResourceAliasWithContext(ctx, &resourcecontrollerv2.GetResourceAliasOptions{
+		ID: &id,
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_alias.getResourceAlias", "query_error", err, "resp", resp)
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return *result, nil
+}
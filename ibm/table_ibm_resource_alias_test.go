@@ -0,0 +1,28 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestResourceAlias(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_aliases", "resource_controller/resource_aliases.json")
+	conn := newTestConnection(t, cloud.config())
+
+	// An alias which was never updated or deleted has neither date
+	rows := conn.mustQuery("ibm_resource_alias", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"account_id":           testAccountID,
+		"name":                 "my-key-protect-alias",
+		"guid":                 "3a4b5c6d-7e8f-4a9b-8c0d-1e2f3a4b5c6d",
+		"state":                "active",
+		"resource_instance_id": "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d::",
+		"region_instance_id":   "7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e",
+		"created_at":           "2023-02-06T08:30:00Z",
+		"updated_at":           nil,
+		"deleted_at":           nil,
+	})
+}
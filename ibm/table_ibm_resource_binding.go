@@ -0,0 +1,169 @@
+package ibm
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmResourceBinding(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_resource_binding",
+		Description: "A resource binding connects the credentials of a resource alias to an application, such as a Cloud Foundry or Code Engine application.",
+		List: &plugin.ListConfig{
+			Hydrate: listResourceBinding,
+			Tags:    serviceTags(endpointResourceController),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+				{Name: "guid", Require: plugin.Optional},
+				{Name: "resource_group_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "region_binding_id", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getResourceBinding,
+			Tags:       serviceTags(endpointResourceController),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The human-readable name of the binding."},
+			{Name: "id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: "The ID associated with the binding."},
+			{Name: "guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("GUID"), Description: "When you create a new binding, a globally unique identifier (GUID) is assigned."},
+			{Name: "crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("CRN"), Description: "The full Cloud Resource Name (CRN) associated with the binding."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The state of the binding."},
+			{Name: "source_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceCRN"), Description: "The CRN of the resource alias the binding was created for."},
+			{Name: "target_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("TargetCRN"), Description: "The CRN of the application the alias is bound to, e.g. a Cloud Foundry application."},
+			// Other columns
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(ensureTimestamp), Description: "The date when the binding was created."},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The subject who created the binding."},
+			{Name: "deleted_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DeletedAt").Transform(ensureTimestamp), Description: "The date when the binding was deleted."},
+			{Name: "deleted_by", Type: proto.ColumnType_STRING, Description: "The subject who deleted the binding."},
+			{Name: "iam_compatible", Type: proto.ColumnType_BOOL, Description: "Specifies whether the binding's credentials support IAM."},
+			{Name: "iam_role_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamRoleCRN"), Description: "The CRN of the IAM role of the credentials."},
+			{Name: "iam_serviceid_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamServiceidCRN"), Description: "The CRN of the IAM service ID of the credentials."},
+			{Name: "migrated", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the binding was migrated from a previous CF instance."},
+			{Name: "region_binding_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("RegionBindingCRN"), Description: "The CRN of the binding in the specific target environment."},
+			{Name: "region_binding_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("RegionBindingID"), Description: "The ID of the binding in the specific target environment."},
+			{Name: "resource_alias_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceAliasURL"), Description: "The relative path to the resource alias the binding was created for."},
+			{Name: "resource_group_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupID"), Description: "The short ID of the resource group."},
+			{Name: "resource_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceID"), Description: "The unique ID of the offering."},
+			{Name: "role", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamRoleCRN").Transform(roleFromCRN), Description: "The name of the IAM role of the credentials, e.g. Writer."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(ensureTimestamp), Description: "The date when the binding was last updated."},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The subject who updated the binding."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "When you created a new binding, a relative URL path is created identifying the location of the binding."},
+			{Name: "credentials", Type: proto.ColumnType_JSON, Hydrate: getResourceCredentials, Transform: transform.FromValue(), Description: "The credentials of the binding, with every secret value redacted unless the connection sets show_credentials."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listResourceBinding(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_binding.listResourceBinding", "connection_error", err)
+		return nil, err
+	}
+
+	// 100 is the maximum number of results per page
+	limit := int64(100)
+	if d.QueryContext.Limit != nil && *d.QueryContext.Limit < limit {
+		limit = *d.QueryContext.Limit
+	}
+
+	opts := &resourcecontrollerv2.ListResourceBindingsOptions{
+		Limit: core.Int64Ptr(limit),
+	}
+
+	// Additional filters
+	if value := d.EqualsQualString("name"); value != "" {
+		opts.SetName(value)
+	}
+	if value := d.EqualsQualString("guid"); value != "" {
+		opts.SetGUID(value)
+	}
+	if value := d.EqualsQualString("resource_group_id"); value != "" {
+		opts.SetResourceGroupID(value)
+	}
+	if value := d.EqualsQualString("resource_id"); value != "" {
+		opts.SetResourceID(value)
+	}
+	if value := d.EqualsQualString("region_binding_id"); value != "" {
+		opts.SetRegionBindingID(value)
+	}
+
+	for {
+		result, resp, err := conn.ListResourceBindingsWithContext(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_binding.listResourceBinding", "query_error", err, "resp", resp)
+			return nil, err
+		}
+
+		for _, i := range result.Resources {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		// The next page is requested with the start token of the next URL
+		start, err := core.GetQueryParam(result.NextURL, "start")
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_binding.listResourceBinding", "next_url_error", err)
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		opts.Start = start
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getResourceBinding(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// No inputs
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_binding.getResourceBinding", "connection_error", err)
+		return nil, err
+	}
+
+	result, resp, err := conn.GetResourceBindingWithContext(ctx, &resourcecontrollerv2.GetResourceBindingOptions{
+		ID: &id,
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_binding.getResourceBinding", "query_error", err, "resp", resp)
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return *result, nil
+}
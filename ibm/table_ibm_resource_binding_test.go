@@ -0,0 +1,33 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestResourceBinding(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_bindings", "resource_controller/resource_bindings.json")
+	conn := newTestConnection(t, cloud.config())
+
+	// A binding which was never updated or deleted has neither date
+	rows := conn.mustQuery("ibm_resource_binding", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"account_id":        testAccountID,
+		"name":              "my-key-protect-binding",
+		"guid":              "9e8d7c6b-5a4f-4e3d-9c2b-1a0f9e8d7c6b",
+		"state":             "active",
+		"region_binding_id": "0f1e2d3c-4b5a-4968-8776-655443322110",
+		"role":              "Reader",
+		"created_at":        "2023-02-06T08:45:00Z",
+		"updated_at":        nil,
+		"deleted_at":        nil,
+		"credentials": map[string]interface{}{
+			"apikey":            "REDACTED",
+			"iam_role_crn":      "crn:v1:bluemix:public:iam::::serviceRole:Reader",
+			"iam_serviceid_crn": "crn:v1:bluemix:public:iam-identity::a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::serviceid:ServiceId-7a8b9c0d",
+		},
+	})
+}
@@ -19,6 +19,7 @@ func tableIbmResourceGroup(ctx context.Context) *plugin.Table {
 		Description: "A resource group is a way for you to organize your account resources in customizable groupings",
 		List: &plugin.ListConfig{
 			Hydrate: listResourceGroup,
+			Tags:    serviceTags(endpointResourceManager),
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "name",
@@ -31,6 +32,9 @@ func tableIbmResourceGroup(ctx context.Context) *plugin.Table {
 				},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
 		Columns: commonColumns([]*plugin.Column{
 			// Top columns
 			{Name: "id", Type: proto.ColumnType_STRING, Description: "An alpha-numeric value identifying the resource group."},
@@ -46,11 +50,13 @@ func tableIbmResourceGroup(ctx context.Context) *plugin.Table {
 			{Name: "quota_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("QuotaURL"), Description: "The URL to access the quota details that associated with the resource group."},
 			{Name: "teams_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("TeamsURL"), Description: "The URL to access the team details that associated with the resource group."},
 			{Name: "resource_linkages", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceLinkages"), Description: "An array of the resources that linked to the resource group."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
 
 			// Standard columns
 			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
 			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
-			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceGroupTags, Transform: transform.FromValue(), Description: resourceInterfaceDescription("tags")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
 		}),
 	}
 }
@@ -116,39 +122,3 @@ func listResourceGroup(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 }
 
 //// HYDRATE FUNCTIONS
-
-func getResourceGroupTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	data := h.Item.(resourcemanagerv2.ResourceGroup)
-	conn, err := tagService(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("ibm_resource_group.getResourceGroupTags", "connection_error", err)
-		return nil, err
-	}
-
-	opts := conn.NewListTagsOptions()
-	opts.SetLimit(100)
-	opts.SetProviders([]string{"ghost"})
-	opts.SetOrderByName("asc")
-	opts.SetAttachedTo(*data.CRN)
-	opts.SetOffset(0)
-
-	tags := []string{}
-
-	for {
-		result, resp, err := conn.ListTagsWithContext(ctx, opts)
-		if err != nil {
-			plugin.Logger(ctx).Error("ibm_resource_group.getResourceGroupTags", "query_error", err, "resp", resp)
-			return nil, err
-		}
-		for _, i := range result.Items {
-			tags = append(tags, *i.Name)
-		}
-		length := int64(len(tags))
-		if length >= *result.TotalCount {
-			break
-		}
-		opts.SetOffset(length)
-	}
-
-	return tags, nil
-}
@@ -0,0 +1,194 @@
+package ibm
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmResourceInstance(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_resource_instance",
+		Description: "A resource instance is an instance of an IBM Cloud service, such as a database or a Key Protect instance.",
+		List: &plugin.ListConfig{
+			Hydrate: listResourceInstance,
+			Tags:    serviceTags(endpointResourceController),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+				{Name: "guid", Require: plugin.Optional},
+				{Name: "resource_group_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_plan_id", Require: plugin.Optional},
+				{Name: "type", Require: plugin.Optional},
+				{Name: "sub_type", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getResourceInstance,
+			Tags:       serviceTags(endpointResourceController),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getResourceTags, Tags: serviceTags(endpointGlobalSearch)},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The human-readable name of the instance."},
+			{Name: "id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: "The ID associated with the instance."},
+			{Name: "guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("GUID"), Description: "When you create a new resource, a globally unique identifier (GUID) is assigned."},
+			{Name: "crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("CRN"), Description: "The full Cloud Resource Name (CRN) associated with the instance."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The current state of the instance. For example, if the instance is deleted, it will return removed."},
+			{Name: "type", Type: proto.ColumnType_STRING, Description: "The type of the instance, e.g. service_instance."},
+			{Name: "sub_type", Type: proto.ColumnType_STRING, Description: "The sub-type of instance, e.g. cfaas."},
+			// Other columns
+			{Name: "allow_cleanup", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the resource instance should be deleted (cleaned up) during the processing of a region instance delete call."},
+			{Name: "controlled_by", Type: proto.ColumnType_STRING, Description: "The subsystem controlling the resource instance."},
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(ensureTimestamp), Description: "The date when the instance was created."},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The subject who created the instance."},
+			{Name: "dashboard_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DashboardURL"), Description: "The resource-broker-provided URL to access administrative features of the instance."},
+			{Name: "deleted_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DeletedAt").Transform(ensureTimestamp), Description: "The date when the instance was deleted."},
+			{Name: "deleted_by", Type: proto.ColumnType_STRING, Description: "The subject who deleted the instance."},
+			{Name: "locked", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the resource instance is locked or not."},
+			{Name: "location", Type: proto.ColumnType_STRING, Transform: transform.FromField("RegionID"), Description: "The location or region of the instance, e.g. us-south or global."},
+			{Name: "migrated", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the resource instance was migrated from a previous CF instance."},
+			{Name: "resource_group_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupCRN"), Description: "The long ID (full CRN) of the resource group."},
+			{Name: "resource_group_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupID"), Description: "The short ID of the resource group."},
+			{Name: "resource_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceID"), Description: "The unique ID of the offering, e.g. the ID of the Key Protect service."},
+			{Name: "resource_plan_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourcePlanID"), Description: "The unique ID of the plan associated with the offering."},
+			{Name: "restored_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("RestoredAt").Transform(ensureTimestamp), Description: "The date when the instance under reclamation was restored."},
+			{Name: "restored_by", Type: proto.ColumnType_STRING, Description: "The subject who restored the instance back from reclamation."},
+			{Name: "scheduled_reclaim_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("ScheduledReclaimAt").Transform(ensureTimestamp), Description: "The date when the instance was scheduled for reclamation."},
+			{Name: "scheduled_reclaim_by", Type: proto.ColumnType_STRING, Description: "The subject who initiated the instance reclamation."},
+			{Name: "target_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("TargetCRN"), Description: "The full deployment CRN as defined in the global catalog."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(ensureTimestamp), Description: "The date when the instance was last updated."},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The subject who updated the instance."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "When you provision a new resource, a relative URL path is created identifying the location of the instance."},
+			{Name: "extensions", Type: proto.ColumnType_JSON, Description: "Additional instance properties, contributed by the service and/or platform, are represented as key-value pairs."},
+			{Name: "last_operation", Type: proto.ColumnType_JSON, Description: "The status of the last operation requested on the instance."},
+			{Name: "plan_history", Type: proto.ColumnType_JSON, Description: "The plan history of the instance."},
+			{Name: "access_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("AccessTags"), Description: "The access tags attached to the resource, which are used to control access with IAM policies."},
+			{Name: "service_tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("ServiceTags"), Description: "The service tags attached to the resource by IBM Cloud services."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
+			{Name: "tags", Type: proto.ColumnType_JSON, Hydrate: getResourceTags, Transform: transform.FromField("Tags").Transform(tagsToMap), Description: resourceInterfaceDescription("tags")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listResourceInstance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_instance.listResourceInstance", "connection_error", err)
+		return nil, err
+	}
+
+	// 100 is the maximum number of results per page
+	limit := int64(100)
+	if d.QueryContext.Limit != nil && *d.QueryContext.Limit < limit {
+		limit = *d.QueryContext.Limit
+	}
+
+	opts := &resourcecontrollerv2.ListResourceInstancesOptions{
+		Limit: core.Int64Ptr(limit),
+	}
+
+	// Additional filters
+	if value := d.EqualsQualString("name"); value != "" {
+		opts.SetName(value)
+	}
+	if value := d.EqualsQualString("guid"); value != "" {
+		opts.SetGUID(value)
+	}
+	if value := d.EqualsQualString("resource_group_id"); value != "" {
+		opts.SetResourceGroupID(value)
+	}
+	if value := d.EqualsQualString("resource_id"); value != "" {
+		opts.SetResourceID(value)
+	}
+	if value := d.EqualsQualString("resource_plan_id"); value != "" {
+		opts.SetResourcePlanID(value)
+	}
+	if value := d.EqualsQualString("type"); value != "" {
+		opts.SetType(value)
+	}
+	if value := d.EqualsQualString("sub_type"); value != "" {
+		opts.SetSubType(value)
+	}
+	if value := d.EqualsQualString("state"); value != "" {
+		opts.SetState(value)
+	}
+
+	for {
+		result, resp, err := conn.ListResourceInstancesWithContext(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_instance.listResourceInstance", "query_error", err, "resp", resp)
+			return nil, err
+		}
+
+		for _, i := range result.Resources {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		// The next page is requested with the start token of the next URL
+		start, err := core.GetQueryParam(result.NextURL, "start")
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_instance.listResourceInstance", "next_url_error", err)
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		opts.Start = start
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getResourceInstance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// No inputs
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_instance.getResourceInstance", "connection_error", err)
+		return nil, err
+	}
+
+	result, resp, err := conn.GetResourceInstanceWithContext(ctx, &resourcecontrollerv2.GetResourceInstanceOptions{
+		ID: &id,
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_instance.getResourceInstance", "query_error", err, "resp", resp)
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return *result, nil
+}
@@ -0,0 +1,39 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestResourceInstance(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_instances", "resource_controller/resource_instances.json")
+	conn := newTestConnection(t, cloud.config())
+
+	// Active instances are not deleted, restored or scheduled for reclamation
+	rows := conn.mustQuery("ibm_resource_instance", nil)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	instances := rowsByColumn(t, rows, "name")
+	assertColumns(t, instances["my-key-protect"], map[string]interface{}{
+		"account_id":           testAccountID,
+		"guid":                 testKmsInstanceID,
+		"crn":                  "crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d::",
+		"state":                "active",
+		"type":                 "service_instance",
+		"sub_type":             "kms",
+		"location":             "us-south",
+		"created_at":           "2023-02-01T10:15:30Z",
+		"updated_at":           "2023-02-01T10:16:02Z",
+		"deleted_at":           nil,
+		"restored_at":          nil,
+		"scheduled_reclaim_at": nil,
+	})
+	assertColumns(t, instances["my-object-storage"], map[string]interface{}{
+		"guid":                 "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a",
+		"location":             "global",
+		"deleted_at":           nil,
+		"restored_at":          nil,
+		"scheduled_reclaim_at": nil,
+	})
+}
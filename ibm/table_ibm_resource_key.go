@@ -0,0 +1,245 @@
+package ibm
+
+import (
+	"context"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmResourceKey(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_resource_key",
+		Description: "A resource key holds the service credentials of a resource instance.",
+		List: &plugin.ListConfig{
+			Hydrate: listResourceKey,
+			Tags:    serviceTags(endpointResourceController),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional},
+				{Name: "guid", Require: plugin.Optional},
+				{Name: "resource_group_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getResourceKey,
+			Tags:       serviceTags(endpointResourceController),
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The human-readable name of the key."},
+			{Name: "id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: "The ID associated with the key."},
+			{Name: "guid", Type: proto.ColumnType_STRING, Transform: transform.FromField("GUID"), Description: "When you create a new key, a globally unique identifier (GUID) is assigned."},
+			{Name: "crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("CRN"), Description: "The full Cloud Resource Name (CRN) associated with the key."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The state of the key."},
+			{Name: "source_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("SourceCRN"), Description: "The CRN of the resource instance or alias the key was created for."},
+			// Other columns
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(ensureTimestamp), Description: "The date when the key was created."},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The subject who created the key."},
+			{Name: "deleted_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DeletedAt").Transform(ensureTimestamp), Description: "The date when the key was deleted."},
+			{Name: "deleted_by", Type: proto.ColumnType_STRING, Description: "The subject who deleted the key."},
+			{Name: "iam_apikey_description", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamApikeyDescription"), Description: "The description of the generated API key."},
+			{Name: "iam_apikey_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamApikeyName"), Description: "The name of the generated API key."},
+			{Name: "iam_compatible", Type: proto.ColumnType_BOOL, Description: "Specifies whether the key's credentials support IAM."},
+			{Name: "iam_role_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamRoleCRN"), Description: "The CRN of the IAM role of the credentials."},
+			{Name: "iam_serviceid_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamServiceidCRN"), Description: "The CRN of the IAM service ID of the credentials."},
+			{Name: "migrated", Type: proto.ColumnType_BOOL, Description: "A boolean that dictates if the key was migrated from a previous CF instance."},
+			{Name: "resource_alias_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceAliasURL"), Description: "The relative path to the resource alias the key was created for."},
+			{Name: "resource_group_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupID"), Description: "The short ID of the resource group."},
+			{Name: "resource_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceID"), Description: "The unique ID of the offering."},
+			{Name: "resource_instance_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceInstanceURL"), Description: "The relative path to the resource instance the key was created for."},
+			{Name: "role", Type: proto.ColumnType_STRING, Transform: transform.FromField("Credentials.IamRoleCRN").Transform(roleFromCRN), Description: "The name of the IAM role of the credentials, e.g. Writer."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(ensureTimestamp), Description: "The date when the key was last updated."},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The subject who updated the key."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "When you created a new key, a relative URL path is created identifying the location of the key."},
+			{Name: "credentials", Type: proto.ColumnType_JSON, Hydrate: getResourceCredentials, Transform: transform.FromValue(), Description: "The credentials of the key, with every secret value redacted unless the connection sets show_credentials."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
+			{Name: "akas", Type: proto.ColumnType_JSON, Transform: transform.FromField("CRN").Transform(ensureStringArray), Description: resourceInterfaceDescription("akas")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listResourceKey(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_key.listResourceKey", "connection_error", err)
+		return nil, err
+	}
+
+	// 100 is the maximum number of results per page
+	limit := int64(100)
+	if d.QueryContext.Limit != nil && *d.QueryContext.Limit < limit {
+		limit = *d.QueryContext.Limit
+	}
+
+	opts := &resourcecontrollerv2.ListResourceKeysOptions{
+		Limit: core.Int64Ptr(limit),
+	}
+
+	// Additional filters
+	if value := d.EqualsQualString("name"); value != "" {
+		opts.SetName(value)
+	}
+	if value := d.EqualsQualString("guid"); value != "" {
+		opts.SetGUID(value)
+	}
+	if value := d.EqualsQualString("resource_group_id"); value != "" {
+		opts.SetResourceGroupID(value)
+	}
+	if value := d.EqualsQualString("resource_id"); value != "" {
+		opts.SetResourceID(value)
+	}
+
+	for {
+		result, resp, err := conn.ListResourceKeysWithContext(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_key.listResourceKey", "query_error", err, "resp", resp)
+			return nil, err
+		}
+
+		for _, i := range result.Resources {
+			d.StreamListItem(ctx, i)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		// The next page is requested with the start token of the next URL
+		start, err := core.GetQueryParam(result.NextURL, "start")
+		if err != nil {
+			plugin.Logger(ctx).Error("ibm_resource_key.listResourceKey", "next_url_error", err)
+			return nil, err
+		}
+		if start == nil {
+			break
+		}
+		opts.Start = start
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getResourceKey(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+
+	// No inputs
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_key.getResourceKey", "connection_error", err)
+		return nil, err
+	}
+
+	result, resp, err := conn.GetResourceKeyWithContext(ctx, &resourcecontrollerv2.GetResourceKeyOptions{
+		ID: &id,
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_key.getResourceKey", "query_error", err, "resp", resp)
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return *result, nil
+}
+
+// getResourceCredentials returns the credentials of a key or binding. Every
+// secret value is redacted, unless the connection config sets show_credentials.
+func getResourceCredentials(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	value, ok := helpers.GetFieldValueFromInterface(h.Item, "Credentials")
+	if !ok {
+		return nil, nil
+	}
+	credentials, ok := value.(*resourcecontrollerv2.Credentials)
+	if !ok || credentials == nil {
+		return nil, nil
+	}
+
+	ibmConfig := GetConfig(d.Connection)
+	if ibmConfig.ShowCredentials != nil && *ibmConfig.ShowCredentials {
+		return credentialsToMap(credentials), nil
+	}
+	return redactCredentials(credentials), nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// credentialsToMap converts credentials to a map of every property
+func credentialsToMap(credentials *resourcecontrollerv2.Credentials) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for key, value := range credentials.GetProperties() {
+		properties[key] = value
+	}
+	for key, value := range map[string]*string{
+		"apikey":                 credentials.Apikey,
+		"iam_apikey_description": credentials.IamApikeyDescription,
+		"iam_apikey_name":        credentials.IamApikeyName,
+		"iam_role_crn":           credentials.IamRoleCRN,
+		"iam_serviceid_crn":      credentials.IamServiceidCRN,
+	} {
+		if value != nil {
+			properties[key] = *value
+		}
+	}
+	return properties
+}
+
+// redactCredentials converts credentials to a map, where every value except the
+// IAM properties is redacted
+func redactCredentials(credentials *resourcecontrollerv2.Credentials) map[string]interface{} {
+	redacted := credentialsToMap(credentials)
+	for key, value := range redacted {
+		// The IAM properties are not secret
+		switch key {
+		case "iam_apikey_description", "iam_apikey_name", "iam_role_crn", "iam_serviceid_crn":
+			continue
+		}
+		redacted[key] = redactedValue(value)
+	}
+	return redacted
+}
+
+// redactedValue keeps the structure of nested credentials, so the available
+// properties can be audited, and redacts every value
+func redactedValue(value interface{}) interface{} {
+	if nested, ok := value.(map[string]interface{}); ok {
+		redacted := make(map[string]interface{}, len(nested))
+		for key, nestedValue := range nested {
+			redacted[key] = redactedValue(nestedValue)
+		}
+		return redacted
+	}
+	return "REDACTED"
+}
+
+// Transform an IAM role CRN, e.g. crn:v1:bluemix:public:iam::::serviceRole:Writer, to the role name
+func roleFromCRN(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	crn, ok := d.Value.(*string)
+	if !ok || crn == nil {
+		return nil, nil
+	}
+	return (*crn)[strings.LastIndex(*crn, ":")+1:], nil
+}
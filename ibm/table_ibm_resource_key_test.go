@@ -0,0 +1,56 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestResourceKey(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_keys", "resource_controller/resource_keys.json")
+	conn := newTestConnection(t, cloud.config())
+
+	// An active key has no deletion date
+	rows := conn.mustQuery("ibm_resource_key", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"account_id": testAccountID,
+		"name":       "my-key-protect-credentials",
+		"guid":       "6c1f2e3d-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+		"state":      "active",
+		"created_at": "2023-02-05T16:20:00Z",
+		"updated_at": "2023-02-05T16:20:00Z",
+		"deleted_at": nil,
+		"role":       "Writer",
+		"credentials": map[string]interface{}{
+			"apikey":                 "REDACTED",
+			"iam_apikey_description": "Auto-generated for key crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:resource-key:6c1f2e3d-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+			"iam_apikey_name":        "my-key-protect-credentials",
+			"iam_role_crn":           "crn:v1:bluemix:public:iam::::serviceRole:Writer",
+			"iam_serviceid_crn":      "crn:v1:bluemix:public:iam-identity::a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::serviceid:ServiceId-3f1e2d4c",
+			"instance_id":            "REDACTED",
+		},
+	})
+}
+
+func TestResourceKeyShowCredentials(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v2/resource_keys", "resource_controller/resource_keys.json")
+	conn := newTestConnection(t, cloud.config()+"\nshow_credentials = true\n")
+
+	rows := conn.mustQuery("ibm_resource_key", nil, "credentials")
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"apikey":                 "not_an_api_key",
+			"iam_apikey_description": "Auto-generated for key crn:v1:bluemix:public:kms:us-south:a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4:5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d:resource-key:6c1f2e3d-4b5a-4c6d-8e7f-9a0b1c2d3e4f",
+			"iam_apikey_name":        "my-key-protect-credentials",
+			"iam_role_crn":           "crn:v1:bluemix:public:iam::::serviceRole:Writer",
+			"iam_serviceid_crn":      "crn:v1:bluemix:public:iam-identity::a/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4::serviceid:ServiceId-3f1e2d4c",
+			"instance_id":            "5f2a9bc1-8e3d-4d6a-9b7c-1e2f3a4b5c6d",
+		},
+	})
+}
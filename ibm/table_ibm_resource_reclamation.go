@@ -0,0 +1,84 @@
+package ibm
+
+import (
+	"context"
+
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmResourceReclamation(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_resource_reclamation",
+		Description: "A reclamation holds a deleted resource instance until it is restored or permanently purged.",
+		List: &plugin.ListConfig{
+			Hydrate: listResourceReclamation,
+			Tags:    serviceTags(endpointResourceController),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_instance_id", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: "The ID associated with the reclamation."},
+			{Name: "entity_crn", Type: proto.ColumnType_STRING, Transform: transform.FromField("EntityCRN"), Description: "The full Cloud Resource Name (CRN) of the reclaimed resource instance."},
+			{Name: "state", Type: proto.ColumnType_STRING, Description: "The state of the reclamation, e.g. SCHEDULED or RESTORING."},
+			{Name: "target_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("TargetTime"), Description: "The date when the resource instance is scheduled to be permanently purged."},
+			// Other columns
+			{Name: "created_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("CreatedAt").Transform(ensureTimestamp), Description: "The date when the reclamation was created."},
+			{Name: "created_by", Type: proto.ColumnType_STRING, Description: "The subject who created the reclamation."},
+			{Name: "entity_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("EntityID"), Description: "The ID of the reclaimed entity."},
+			{Name: "entity_type_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("EntityTypeID"), Description: "The ID of the type of the reclaimed entity."},
+			{Name: "policy_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("PolicyID"), Description: "The ID of the reclamation policy, which sets how long the instance is retained."},
+			{Name: "resource_group_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceGroupID"), Description: "The short ID of the resource group of the reclaimed instance."},
+			{Name: "resource_instance_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("ResourceInstanceID"), Description: "The GUID of the reclaimed resource instance."},
+			{Name: "updated_at", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("UpdatedAt").Transform(ensureTimestamp), Description: "The date when the reclamation was last updated."},
+			{Name: "updated_by", Type: proto.ColumnType_STRING, Description: "The subject who updated the reclamation."},
+			{Name: "custom_properties", Type: proto.ColumnType_JSON, Description: "Additional properties of the reclamation."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("ID"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listResourceReclamation(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := resourceControllerService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_reclamation.listResourceReclamation", "connection_error", err)
+		return nil, err
+	}
+
+	opts := &resourcecontrollerv2.ListReclamationsOptions{}
+
+	// Additional filters
+	if value := d.EqualsQualString("resource_instance_id"); value != "" {
+		opts.SetResourceInstanceID(value)
+	}
+
+	// The reclamations are returned in a single page
+	result, resp, err := conn.ListReclamationsWithContext(ctx, opts)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_resource_reclamation.listResourceReclamation", "query_error", err, "resp", resp)
+		return nil, err
+	}
+
+	for _, i := range result.Resources {
+		d.StreamListItem(ctx, i)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,26 @@
+package ibm
+
+import (
+	"testing"
+)
+
+func TestResourceReclamation(t *testing.T) {
+	cloud := newFakeCloud(t)
+	cloud.ResourceController.fixture("GET /v1/reclamations", "resource_controller/reclamations.json")
+	conn := newTestConnection(t, cloud.config())
+
+	// A reclamation which was never updated has no updated_at
+	rows := conn.mustQuery("ibm_resource_reclamation", nil)
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	assertColumns(t, rows[0], map[string]interface{}{
+		"account_id":           testAccountID,
+		"id":                   "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+		"state":                "SCHEDULED",
+		"resource_instance_id": "4e5f6a7b-8c9d-4e0f-a1b2-c3d4e5f6a7b8",
+		"target_time":          "2023-04-17T10:00:00Z",
+		"created_at":           "2023-04-10T10:00:00Z",
+		"updated_at":           nil,
+	})
+}
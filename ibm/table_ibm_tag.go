@@ -0,0 +1,205 @@
+package ibm
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/IBM/platform-services-go-sdk/globaltaggingv1"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableIbmTag(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "ibm_tag",
+		Description: "User, access and service tags in the IBM Cloud account.",
+		List: &plugin.ListConfig{
+			Hydrate: listTag,
+			Tags:    serviceTags(endpointGlobalTagging),
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tag_type", Require: plugin.Optional},
+				{Name: "attached_to", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{Func: getTagAttachedResources, Tags: serviceTags(endpointGlobalSearch)},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			// Top columns
+			{Name: "name", Type: proto.ColumnType_STRING, Description: "The name of the tag."},
+			{Name: "tag_type", Type: proto.ColumnType_STRING, Description: "The type of the tag. Possible values are user, access and service."},
+			// Other columns
+			{Name: "attached_resource_count", Type: proto.ColumnType_INT, Hydrate: getTagAttachedResources, Transform: transform.FromField("Count"), Description: "The number of resources the tag is attached to."},
+			{Name: "attached_resource_crns", Type: proto.ColumnType_JSON, Hydrate: getTagAttachedResources, Transform: transform.FromField("CRNs"), Description: "The CRNs of the resources the tag is attached to."},
+			{Name: "attached_to", Type: proto.ColumnType_STRING, Transform: transform.FromQual("attached_to"), Description: "The CRN of a resource, to only list the tags attached to it."},
+
+			// Standard columns
+			{Name: "title", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: resourceInterfaceDescription("title")},
+		}),
+	}
+}
+
+type tagInfo struct {
+	Name    string
+	TagType string
+}
+
+type tagAttachments struct {
+	Count int
+	CRNs  []string
+}
+
+//// LIST FUNCTION
+
+func listTag(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create service connection
+	conn, err := tagService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("ibm_tag.listTag", "connection_error", err)
+		return nil, err
+	}
+
+	// Service tags can only be listed for an account
+	accountID, err := getAccountId(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	tagTypes := []string{
+		globaltaggingv1.ListTagsOptionsTagTypeUserConst,
+		globaltaggingv1.ListTagsOptionsTagTypeAccessConst,
+		globaltaggingv1.ListTagsOptionsTagTypeServiceConst,
+	}
+	if tagType := d.EqualsQualString("tag_type"); tagType != "" {
+		// No tags of an unknown type
+		if !slices.Contains(tagTypes, tagType) {
+			return nil, nil
+		}
+		tagTypes = []string{tagType}
+	}
+
+	attachedTo := d.EqualsQualString("attached_to")
+
+	for _, tagType := range tagTypes {
+		opts := conn.NewListTagsOptions()
+		opts.SetTagType(tagType)
+		opts.SetAccountID(accountID.(string))
+		opts.SetLimit(1000)
+		opts.SetOrderByName("asc")
+		opts.SetOffset(0)
+
+		// Access and service tags are only attached to resources in Global Search and Tagging
+		if tagType == globaltaggingv1.ListTagsOptionsTagTypeUserConst {
+			opts.SetProviders([]string{"ghost"})
+		}
+		if attachedTo != "" {
+			opts.SetAttachedTo(attachedTo)
+		}
+
+		count := int64(0)
+		for {
+			result, resp, err := conn.ListTagsWithContext(ctx, opts)
+			if err != nil {
+				plugin.Logger(ctx).Error("ibm_tag.listTag", "query_error", err, "resp", resp)
+				return nil, err
+			}
+			for _, i := range result.Items {
+				d.StreamListItem(ctx, &tagInfo{
+					Name:    *i.Name,
+					TagType: tagType,
+				})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+			count += int64(len(result.Items))
+			if len(result.Items) == 0 || result.TotalCount == nil || count >= *result.TotalCount {
+				break
+			}
+			opts.SetOffset(count)
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+// getTagAttachedResources looks up the resources of a tag in the tags of the whole account
+func getTagAttachedResources(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	tag := h.Item.(*tagInfo)
+
+	index, err := listTagIndex(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	crns := index.(tagIndex)[tag.TagType][tag.Name]
+	if crns == nil {
+		crns = []string{}
+	}
+
+	return &tagAttachments{
+		Count: len(crns),
+		CRNs:  crns,
+	}, nil
+}
+
+// tagIndex holds the sorted CRNs of the resources of each tag, keyed by tag
+// type and then by tag name
+type tagIndex map[string]map[string][]string
+
+// the index is built once per connection, rather than once per tag
+var listTagIndexMemoize = plugin.HydrateFunc(listTagIndexUncached).Memoize(memoize.WithCacheKeyFunction(listTagIndexCacheKey))
+
+func listTagIndex(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return listTagIndexMemoize(ctx, d, h)
+}
+
+// Build a cache key for the call to listTagIndex.
+func listTagIndexCacheKey(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	cacheKey := "listTagIndex"
+	return cacheKey, nil
+}
+
+func listTagIndexUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	accountTags, err := listAccountTags(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	return buildTagIndex(accountTags.(map[string]*resourceTags)), nil
+}
+
+// buildTagIndex inverts the tags of each resource into the resources of each tag
+func buildTagIndex(accountTags map[string]*resourceTags) tagIndex {
+	index := tagIndex{
+		globaltaggingv1.ListTagsOptionsTagTypeUserConst:    {},
+		globaltaggingv1.ListTagsOptionsTagTypeAccessConst:  {},
+		globaltaggingv1.ListTagsOptionsTagTypeServiceConst: {},
+	}
+	add := func(tagType string, crn string, names []string) {
+		for _, name := range names {
+			index[tagType][name] = append(index[tagType][name], crn)
+		}
+	}
+	for crn, tags := range accountTags {
+		add(globaltaggingv1.ListTagsOptionsTagTypeUserConst, crn, tags.Tags)
+		add(globaltaggingv1.ListTagsOptionsTagTypeAccessConst, crn, tags.AccessTags)
+		add(globaltaggingv1.ListTagsOptionsTagTypeServiceConst, crn, tags.ServiceTags)
+	}
+	for _, names := range index {
+		for name, crns := range names {
+			sort.Strings(crns)
+			names[name] = slices.Compact(crns)
+		}
+	}
+	return index
+}
@@ -0,0 +1,40 @@
+package ibm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildTagIndex(t *testing.T) {
+	crnVpc := "crn:v1:bluemix:public:is:us-south:a/" + testAccountID + "::vpc:r006-1"
+	crnKey := "crn:v1:bluemix:public:kms:us-south:a/" + testAccountID + ":instance:key:1"
+
+	index := buildTagIndex(map[string]*resourceTags{
+		crnVpc: {
+			Tags:        []string{"env:dev", "shared"},
+			AccessTags:  []string{"project:network"},
+			ServiceTags: []string{},
+		},
+		crnKey: {
+			Tags:        []string{"env:dev", "env:dev"},
+			AccessTags:  []string{},
+			ServiceTags: []string{"schematics:managed"},
+		},
+	})
+
+	want := tagIndex{
+		"user": {
+			"env:dev": {crnVpc, crnKey},
+			"shared":  {crnVpc},
+		},
+		"access": {
+			"project:network": {crnVpc},
+		},
+		"service": {
+			"schematics:managed": {crnKey},
+		},
+	}
+	if !reflect.DeepEqual(index, want) {
+		t.Errorf("got %v, want %v", index, want)
+	}
+}
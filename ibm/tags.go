@@ -0,0 +1,141 @@
+package ibm
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// resourceTags holds the tags attached to a resource
+type resourceTags struct {
+	Tags        []string
+	AccessTags  []string
+	ServiceTags []string
+}
+
+// getResourceTags returns the tags of the resource of a row, looked up by its
+// CRN in the tags of the whole account
+func getResourceTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	tags := &resourceTags{
+		Tags:        []string{},
+		AccessTags:  []string{},
+		ServiceTags: []string{},
+	}
+
+	crn, ok := helpers.GetFieldValueFromInterface(h.Item, "CRN")
+	if !ok {
+		return tags, nil
+	}
+	crnString, ok := crn.(*string)
+	if !ok || crnString == nil {
+		return tags, nil
+	}
+
+	accountTags, err := listAccountTags(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	if found, ok := accountTags.(map[string]*resourceTags)[*crnString]; ok {
+		return found, nil
+	}
+
+	return tags, nil
+}
+
+// the tags of the account are loaded once per connection, rather than once per row
+var listAccountTagsMemoize = plugin.HydrateFunc(listAccountTagsUncached).Memoize(memoize.WithCacheKeyFunction(listAccountTagsCacheKey))
+
+func listAccountTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return listAccountTagsMemoize(ctx, d, h)
+}
+
+// Build a cache key for the call to listAccountTags.
+func listAccountTagsCacheKey(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	cacheKey := "listAccountTags"
+	return cacheKey, nil
+}
+
+// listAccountTagsUncached searches the account for every tagged resource and
+// returns their tags, keyed by CRN
+func listAccountTagsUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	conn, err := globalSearchService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("listAccountTags", "connection_error", err)
+		return nil, err
+	}
+
+	accountID, err := getAccountId(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1000 is the maximum number of results per call
+	opts := conn.NewSearchOptions()
+	opts.SetQuery("tags:* OR access_tags:* OR service_tags:*")
+	opts.SetFields([]string{"crn", "tags", "access_tags", "service_tags"})
+	opts.SetAccountID(accountID.(string))
+	opts.SetLimit(1000)
+
+	tagMap := map[string]*resourceTags{}
+
+	for {
+		result, resp, err := conn.SearchWithContext(ctx, opts)
+		if err != nil {
+			plugin.Logger(ctx).Error("listAccountTags", "query_error", err, "resp", resp)
+			return nil, err
+		}
+		for _, item := range result.Items {
+			if item.CRN == nil {
+				continue
+			}
+			tagMap[*item.CRN] = &resourceTags{
+				Tags:        searchResultTags(item.GetProperty("tags")),
+				AccessTags:  searchResultTags(item.GetProperty("access_tags")),
+				ServiceTags: searchResultTags(item.GetProperty("service_tags")),
+			}
+		}
+		// An empty page signals the end of the results
+		if len(result.Items) == 0 || result.SearchCursor == nil {
+			break
+		}
+		opts.SetSearchCursor(*result.SearchCursor)
+	}
+
+	return tagMap, nil
+}
+
+// searchResultTags converts a tag property of a search result to a list of tags
+func searchResultTags(property interface{}) []string {
+	tags := []string{}
+	values, ok := property.([]interface{})
+	if !ok {
+		return tags
+	}
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Transform user tags to a map of the values of each key. A "key:value" tag is
+// split at the first colon, a tag without a colon has an empty value. IBM Cloud
+// allows several tags with the same key, e.g. "env:dev" and "env:prod", so every
+// key maps to the list of its values.
+func tagsToMap(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]string)
+	if !ok {
+		return nil, nil
+	}
+	tagMap := make(map[string][]string, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, ":")
+		tagMap[key] = append(tagMap[key], value)
+	}
+	return tagMap, nil
+}
@@ -0,0 +1,36 @@
+package ibm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func TestTagsToMap(t *testing.T) {
+	tests := []struct {
+		name string
+		tags interface{}
+		want interface{}
+	}{
+		{name: "key and value", tags: []string{"env:dev", "team:network"}, want: map[string][]string{"env": {"dev"}, "team": {"network"}}},
+		{name: "split at the first colon", tags: []string{"owner:user:alice"}, want: map[string][]string{"owner": {"user:alice"}}},
+		{name: "tag without a value", tags: []string{"shared"}, want: map[string][]string{"shared": {""}}},
+		{name: "repeated key", tags: []string{"env:dev", "env:prod"}, want: map[string][]string{"env": {"dev", "prod"}}},
+		{name: "no tags", tags: []string{}, want: map[string][]string{}},
+		{name: "not a list of tags", tags: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tagsToMap(context.Background(), &transform.TransformData{Value: tt.tags})
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/IBM-Cloud/bluemix-go/http"
 	"github.com/IBM-Cloud/bluemix-go/rest"
 	"github.com/IBM-Cloud/bluemix-go/session"
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/go-openapi/strfmt"
 	"github.com/golang-jwt/jwt/v4"
 
@@ -58,20 +59,69 @@ func configApiKey(_ context.Context, d *plugin.QueryData) (string, error) {
 	return "", errors.New("api_key must be configured")
 }
 
-func connect(ctx context.Context, d *plugin.QueryData) (*session.Session, error) {
+// connect returns the bluemix session of the connection, limited to the request
+// rate of the given service. Global services pass an empty region.
+func connect(ctx context.Context, d *plugin.QueryData, service string, region string) (*session.Session, error) {
+	conn, err := connectSession(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The clients of every service share the session, so limit a copy of it
+	httpClient := *conn.Config.HTTPClient
+	httpClient.Transport = newRateLimitTransport(d, service, region, httpClient.Transport)
+	limited := conn.Copy()
+	limited.Config.HTTPClient = &httpClient
+
+	return limited, nil
+}
+
+func connectSession(ctx context.Context, d *plugin.QueryData) (*session.Session, error) {
 	// Load connection from cache, which preserves throttling protection etc
 	cacheKey := "ibm"
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*session.Session), nil
 	}
 
-	apiKey, err := configApiKey(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
+	ibmConfig := GetConfig(d.Connection)
+	maxRetries := configMaxRetries(ibmConfig)
+	retryDelay := configMaxBackoff(ibmConfig)
 	conf := &bluemix.Config{
-		BluemixAPIKey: apiKey,
+		TokenProviderEndpoint: core.StringPtr(serviceEndpoint(d, endpointIAM, "")),
+		// The session only retries timeouts, with a fixed delay
+		MaxRetries: &maxRetries,
+		RetryDelay: &retryDelay,
+		HTTPClient: &gohttp.Client{
+			Transport: newRetryLoggingTransport(ctx, nil),
+		},
+	}
+	if configEndpointType(ibmConfig) == endpointTypePrivate {
+		conf.Visibility = endpointTypePrivate
+	}
+
+	iamAuthenticator, isAPIKey := authenticator.(*core.IamAuthenticator)
+	if isAPIKey {
+		conf.BluemixAPIKey = iamAuthenticator.ApiKey
+	} else {
+		accessToken, err := getAuthenticatorToken(authenticator)
+		if err != nil {
+			return nil, err
+		}
+		// Trusted profile tokens cannot be refreshed, so every request is
+		// authenticated with a current token from the authenticator instead
+		conf.IAMAccessToken = "Bearer " + accessToken
+		conf.IAMRefreshToken = "not_supported"
+		conf.HTTPClient = &gohttp.Client{
+			Transport: &authenticatorTransport{
+				authenticator: authenticator,
+				transport:     newRetryLoggingTransport(ctx, nil),
+			},
+		}
 	}
 
 	conn, err := session.New(conf)
@@ -79,9 +129,11 @@ func connect(ctx context.Context, d *plugin.QueryData) (*session.Session, error)
 		return nil, err
 	}
 
-	err = authenticateAPIKey(conn)
-	if err != nil {
-		return nil, err
+	if isAPIKey {
+		err = authenticateAPIKey(conn)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Save to cache
@@ -100,14 +152,13 @@ type UserConfig struct {
 	generation  int    `default:"2"`
 }
 
-func fetchUserDetails(sess *session.Session, generation int) (*UserConfig, error) {
-	config := sess.Config
+func fetchUserDetails(accessToken string, generation int) (*UserConfig, error) {
 	user := UserConfig{}
 	var bluemixToken string
-	if strings.HasPrefix(config.IAMAccessToken, "Bearer") {
-		bluemixToken = config.IAMAccessToken[7:len(config.IAMAccessToken)]
+	if strings.HasPrefix(accessToken, "Bearer") {
+		bluemixToken = accessToken[7:len(accessToken)]
 	} else {
-		bluemixToken = config.IAMAccessToken
+		bluemixToken = accessToken
 	}
 	token, err := jwt.Parse(bluemixToken, func(token *jwt.Token) (interface{}, error) {
 		return "", nil
@@ -174,13 +225,19 @@ func getAccountIdUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		return cachedData.(string), nil
 	}
 
-	conn, err := connect(ctx, d)
+	authenticator, err := getAuthenticator(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getAccountId", "connection_error", err)
+		return "", err
+	}
+
+	accessToken, err := getAuthenticatorToken(authenticator)
 	if err != nil {
 		plugin.Logger(ctx).Error("getAccountId", "connection_error", err)
 		return "", err
 	}
 
-	userInfo, err := fetchUserDetails(conn, 2)
+	userInfo, err := fetchUserDetails(accessToken, 2)
 	if err != nil {
 		plugin.Logger(ctx).Error("ibm_iam_user.listIamUser", "connection_error", err)
 		return nil, err
@@ -197,7 +254,7 @@ func resourceInterfaceDescription(key string) string {
 	case "akas":
 		return "Array of globally unique identifier strings (also known as) for the resource."
 	case "tags":
-		return "A map of tags for the resource."
+		return "A map of the values of each user tag key of the resource."
 	case "title":
 		return "Title of the resource."
 	}
@@ -223,12 +280,38 @@ func ensureStringArray(_ context.Context, d *transform.TransformData) (interface
 	return nil, nil
 }
 
-func ensureTimestamp(ctx context.Context, d *transform.TransformData) (interface{}, error) {
-	if d.Value == nil {
+// Transform the service name of a CRN, e.g. kms
+func crnServiceName(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return crnSegment(d.Value, 4), nil
+}
+
+// Transform the region of a CRN, e.g. us-south
+func crnRegion(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return crnSegment(d.Value, 5), nil
+}
+
+// Transform the service instance of a CRN, e.g. the GUID of a Key Protect instance
+func crnServiceInstance(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return crnSegment(d.Value, 7), nil
+}
+
+// crnSegment returns a segment of a CRN of the form
+// crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource
+func crnSegment(value interface{}, index int) interface{} {
+	segments := strings.Split(types.SafeString(value), ":")
+	if len(segments) <= index || segments[index] == "" {
+		return nil
+	}
+	return segments[index]
+}
+
+// Transform a date time of the IBM Cloud SDKs, which is nil if not set, e.g.
+// the deleted_at of an active resource
+func ensureTimestamp(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	t, ok := d.Value.(*strfmt.DateTime)
+	if !ok || t == nil {
 		return nil, nil
 	}
-	t := d.Value.(*strfmt.DateTime)
-	plugin.Logger(ctx).Warn("ensureTimestamp", "d.Value", t)
 	return t.String(), nil
 }
 